@@ -13,6 +13,8 @@ import (
 	"strings"
 	"unicode"
 	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 /* ----------------------------------------------------------------
@@ -28,6 +30,7 @@ const (
 var (
 	ErrCharacterNotFound        error = errors.New("character not found in alphabet")
 	ErrCharacterIndexOutOfRange error = errors.New("character index greater than alphabet")
+	ErrNotPermutation           error = errors.New("the cipher alphabet is not a permutation of the alphabet")
 )
 
 /* ----------------------------------------------------------------
@@ -44,6 +47,7 @@ type TriState uint8
 type AlphabetModel struct {
 	Name        string
 	alphabet    []rune
+	index       map[rune]int // rune->position for O(1) lookups
 	upperCased  TriState
 	symbolsOnly bool
 }
@@ -58,11 +62,13 @@ type AlphabetModel struct {
 
 // (ctor) an instance of an alphabet made of the given string. It
 // assumes all characters are unique and no trimming is done!
-// However, the alphabet is converted to uppercase.
+// However, the alphabet is converted to uppercase. Like all alphabet
+// models it is kept in Unicode normalization form NFC.
 func NewAlphabetModelCased(alphabet string) *AlphabetModel {
+	runic := []rune(norm.NFC.String(strings.ToUpper(alphabet)))
 	return &AlphabetModel{
-
-		alphabet:    []rune(strings.ToUpper(alphabet)),
+		alphabet:    runic,
+		index:       runeIndex(runic),
 		upperCased:  Yes,
 		symbolsOnly: false,
 	}
@@ -70,8 +76,10 @@ func NewAlphabetModelCased(alphabet string) *AlphabetModel {
 
 // (ctor) alphabet model without case conversion
 func NewAlphabetModel(alphabet string) *AlphabetModel {
+	runic := []rune(norm.NFC.String(alphabet))
 	return &AlphabetModel{
-		alphabet:    []rune(alphabet),
+		alphabet:    runic,
+		index:       runeIndex(runic),
 		upperCased:  Unknown,
 		symbolsOnly: false,
 	}
@@ -80,13 +88,46 @@ func NewAlphabetModel(alphabet string) *AlphabetModel {
 // (ctor) A symbols/punctuation-only alphabet without letters
 // that can be upper/lowercased.
 func NewAlphabetModelForSymbols(alphabet string) *AlphabetModel {
+	runic := []rune(norm.NFC.String(alphabet))
 	return &AlphabetModel{
-		alphabet:    []rune(alphabet),
+		alphabet:    runic,
+		index:       runeIndex(runic),
 		upperCased:  No,
 		symbolsOnly: true,
 	}
 }
 
+// (ctor) A keyword-mixed (deranged) alphabet made from the base
+// alphabet. The distinct letters of the keyword come first, followed
+// by the remaining letters of the base alphabet in their usual order.
+// Keyword characters that are not part of the base alphabet are
+// ignored. The result has the same character set as the base.
+func NewKeyedAlphabet(base *AlphabetModel, keyword string) *AlphabetModel {
+	mixed := make([]rune, 0, base.Length())
+	used := make(map[int]bool, base.Length())
+	// · the keyword letters (without repetitions) go first
+	for _, char := range base.Normalize(keyword) {
+		if at := base.Find(char); at != -1 && !used[at] {
+			used[at] = true
+			mixed = append(mixed, base.alphabet[at])
+		}
+	}
+	// · followed by the rest of the base alphabet
+	for at, char := range base.alphabet {
+		if !used[at] {
+			mixed = append(mixed, char)
+		}
+	}
+
+	return &AlphabetModel{
+		Name:        base.Name,
+		alphabet:    mixed,
+		index:       runeIndex(mixed),
+		upperCased:  base.upperCased,
+		symbolsOnly: base.symbolsOnly,
+	}
+}
+
 /* ----------------------------------------------------------------
  *				P u b l i c		M e t h o d s
  *-----------------------------------------------------------------*/
@@ -115,10 +156,23 @@ func (a *AlphabetModel) Character(index int) (rune, error) {
 	return a.alphabet[index], nil
 }
 
+// the text in Unicode normalization form NFC, the form of the alphabet
+// characters. Decomposed text (E + combining acute) would otherwise
+// not match precomposed letters (É) on a per-rune lookup.
+func (a *AlphabetModel) Normalize(text string) string {
+	return norm.NFC.String(text)
+}
+
 // finds the index of the exact character (case-sensitive).
 // return -1 if not found
 func (a *AlphabetModel) FindExact(char rune) int {
-	return slices.Index(a.alphabet, char)
+	if a.index == nil {
+		return slices.Index(a.alphabet, char)
+	}
+	if at, ok := a.index[char]; ok {
+		return at
+	}
+	return -1
 }
 
 // finds the index of the character (case-insensitive search),
@@ -129,7 +183,7 @@ func (a *AlphabetModel) Find(char rune) int {
 	}
 
 	if unicode.IsLower(char) && a.upperCased == Yes {
-		uc := unicode.ToUpper(char)
+		uc := UpperCase(char)
 		return a.FindExact(uc)
 	}
 
@@ -138,7 +192,27 @@ func (a *AlphabetModel) Find(char rune) int {
 		return a.FindExact(lc)
 	}
 
-	return slices.Index(a.alphabet, char)
+	return a.FindExact(char)
+}
+
+// whether both alphabets contain exactly the same characters,
+// possibly in a different order (e.g. a keyword-mixed alphabet).
+func (a *AlphabetModel) IsPermutationOf(other *AlphabetModel) bool {
+	if other == nil || a.Length() != other.Length() {
+		return false
+	}
+	for _, char := range a.alphabet {
+		if other.FindExact(char) == -1 {
+			return false
+		}
+	}
+	// · repeated characters would leave some of the other out
+	for _, char := range other.alphabet {
+		if a.FindExact(char) == -1 {
+			return false
+		}
+	}
+	return true
 }
 
 func (a *AlphabetModel) FirstChar() rune {
@@ -160,3 +234,30 @@ func (a *AlphabetModel) IsLower() bool {
 /* ----------------------------------------------------------------
  *					F u n c t i o n s
  *-----------------------------------------------------------------*/
+
+// the upper case of a letter like unicode.ToUpper(), except that the
+// German ß becomes ẞ. The simple case mapping of ß is one-way (ẞ to ß)
+// so a lower-cased ẞ would otherwise never be found in an alphabet.
+func UpperCase(char rune) rune {
+	upper := unicode.ToUpper(char)
+	if upper == char && unicode.IsLower(char) {
+		for fold := unicode.SimpleFold(char); fold != char; fold = unicode.SimpleFold(fold) {
+			if unicode.IsUpper(fold) {
+				return fold
+			}
+		}
+	}
+	return upper
+}
+
+// the position of every rune in the alphabet. Should a rune appear
+// more than once the first position is kept, like slices.Index().
+func runeIndex(alphabet []rune) map[rune]int {
+	index := make(map[rune]int, len(alphabet))
+	for at, char := range alphabet {
+		if _, dup := index[char]; !dup {
+			index[char] = at
+		}
+	}
+	return index
+}
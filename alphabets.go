@@ -65,6 +65,16 @@ func RuneString(latin string) string {
 	return string(result)
 }
 
+// Reverses the Unicode characters of a string, for example an
+// alphabet for a counter-clockwise (Atbash) ring.
+func ReverseString(s string) string {
+	runic := []rune(s)
+	for i, j := 0, len(runic)-1; i < j; i, j = i+1, j-1 {
+		runic[i], runic[j] = runic[j], runic[i]
+	}
+	return string(runic)
+}
+
 // The AlphabetFactory function returns an instance of the
 // requested alphabet. The nameOrCode can be the full name
 // or its 2-5 letter id. It is case-sensitive. Returns nil
@@ -155,6 +165,20 @@ func AlphabetFactory(nameOrCode string) *AlphabetModel {
 	return mdl
 }
 
+// The paired alphabet of symbols of the dual disk for the given
+// alphabet of letters. Only English & Spanish (without accents) have
+// one, for any other it returns nil.
+func DualSymbolsFor(letters *AlphabetModel) *AlphabetModel {
+	switch letters.String() {
+	case Alpha_EN:
+		return AlphabetFactory("PU-EN")
+	case Alpha_ES_DUAL:
+		return AlphabetFactory("PU-ES")
+	}
+
+	return nil
+}
+
 // compare the alphabet contents with the predefined list.
 // If found, set its Name and return that name.
 func IdentifyAlphabet(α *AlphabetModel) string {
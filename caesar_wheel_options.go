@@ -23,6 +23,7 @@ var DefaultCaesarWheelOptions = CaesarWheelOptions{
 	Size:            image.Rect(0, 0, 800, 800),
 	Radius:          360.0, // Must be less than half the size (less than the diameter/2)
 	Orthogonal:      true,
+	Reversed:        false,
 	RadialsColor:    NewRGB[uint8](0xd3, 0xd3, 0xd3), // #d3d3d3
 	LettersFontPath: DEFAULT_FONT_BOLD,
 	LettersSize:     30.0,
@@ -43,6 +44,7 @@ type CaesarWheelOptions struct {
 	Size            image.Rectangle
 	Radius          float64
 	Orthogonal      bool
+	Reversed        bool // inner disk runs counter-clockwise (Atbash)
 	RadialsColor    RGB[uint8]
 	LettersFontPath string
 	LettersSize     float64
@@ -66,6 +68,7 @@ func (w *CaesarWheelOptions) String() string {
 	fmt.Fprintf(&sb, "%15s: %s @ %.3f\n", "Text font", w.LettersFontPath, w.LettersSize)
 	fmt.Fprintf(&sb, "%15s: %s @ %.3f\n", "Digit font", w.DigitsFontPath, w.DigitsSize)
 	fmt.Fprintf(&sb, "%15s: %t\n", "Orthogonal", w.Orthogonal)
+	fmt.Fprintf(&sb, "%15s: %t\n", "Reversed", w.Reversed)
 
 	return sb.String()
 }
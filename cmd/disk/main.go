@@ -15,6 +15,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/lordofscripts/caesardisk"
+	"github.com/lordofscripts/caesardisk/crypto"
 	"github.com/lordofscripts/goapp/app"
 )
 
@@ -48,6 +49,10 @@ func Usage() {
 	fmt.Println("\tcaesardisk [options] -text-font FONT.ttf -digit-font FONT.ttf")
 	fmt.Println("Options:")
 	flag.PrintDefaults()
+	fmt.Println("Cipher modes (-mode):")
+	for _, mode := range crypto.CipherModes() {
+		fmt.Printf("\t%s\n", mode)
+	}
 	fmt.Println("Note: Fonts must be TrueType (*.ttf)")
 }
 
@@ -65,8 +70,8 @@ func main() {
 	// the letter at the III o'clock position.
 
 	// I. Command-line flag definition and parsing
-	var flgHelp, flgES, flgRU, flgPT, flgDE, flgGR, flgIT, flgCZ, flgPunct, flgDual bool
-	var flgTextFontPath, flgDigitFontPath, flgAlphabet, flgTitle string
+	var flgHelp, flgES, flgRU, flgPT, flgDE, flgGR, flgIT, flgCZ, flgPunct, flgDual, flgAtbash bool
+	var flgTextFontPath, flgDigitFontPath, flgAlphabet, flgTitle, flgMixKeyword, flgMode string
 	var flgAssemble int
 	flag.Usage = Usage
 	flag.BoolVar(&flgHelp, "help", false, "This help")
@@ -83,6 +88,9 @@ func main() {
 	flag.BoolVar(&flgPunct, "PU", false, "Punctuation and numerical alphabet (overrides -alpha)")
 	// 1.3 flag for custom alphabet
 	flag.StringVar(&flgAlphabet, "alpha", "", "Alphabet defaults to English ASCII alphabet")
+	flag.StringVar(&flgMixKeyword, "mix", "", "Keyword for a mixed (deranged) alphabet on the inner disk")
+	flag.BoolVar(&flgAtbash, "atbash", false, "Inner disk runs counter-clockwise (Atbash/Beaufort disk)")
+	flag.StringVar(&flgMode, "mode", crypto.CaesarMode.String(), "Cipher mode of the disk, i.e. Atbash implies -atbash (other modes contradict it)")
 	// 1.4 flags for output formatting
 	flag.StringVar(&flgTitle, "title", "", "Title (usually disk language or ID)")
 	flag.StringVar(&flgTextFontPath, "text-font", "", "Text font path")
@@ -99,6 +107,18 @@ func main() {
 		var Options caesardisk.CaesarWheelOptions = caesardisk.DefaultCaesarWheelOptions
 		Options.LetterColorAlt = caesardisk.NewRGBFromString("#c13e93") // for inner
 		Options.DigitsSize += 2.0
+		mode, err := crypto.ParseCipherMode(flgMode)
+		if err != nil {
+			app.DieWithError(err, 1)
+		}
+		// -atbash is the shorthand of -mode Atbash, not a modifier of
+		// another mode whose inner disk turns clockwise
+		modeGiven := false
+		flag.Visit(func(f *flag.Flag) { modeGiven = modeGiven || f.Name == "mode" })
+		if flgAtbash && modeGiven && !mode.IsReversed() {
+			app.DieWithError(fmt.Errorf("-atbash contradicts -mode %s", mode), 1)
+		}
+		Options.Reversed = flgAtbash || mode.IsReversed()
 
 		if len(flgDigitFontPath) != 0 {
 			Options.DigitsFontPath = flgDigitFontPath
@@ -229,9 +249,20 @@ func main() {
 			}
 		}
 
+		// -mix the inner (cipher) disk uses a keyword-mixed alphabet
+		alphabetInner := alphabetLet
+		if len(flgMixKeyword) != 0 {
+			base := caesardisk.NewAlphabetModel(alphabetLet)
+			if alphabetLet == strings.ToUpper(alphabetLet) {
+				// case-insensitive keyword for uppercase alphabets
+				base = caesardisk.NewAlphabetModelCased(alphabetLet)
+			}
+			alphabetInner = caesardisk.NewKeyedAlphabet(base, flgMixKeyword).String()
+		}
+
 		// the filename is in base form
 		generateWheel(alphabetLet, "caesar_disk", false, flgDual, Options)
-		generateWheel(alphabetLet, "caesar_disk", true, flgDual, Options)
+		generateWheel(alphabetInner, "caesar_disk", true, flgDual, Options)
 
 		// -assemble
 		if flgAssemble > -1 {
@@ -258,6 +289,9 @@ func main() {
 
 		fmt.Print('\n', Options)
 		fmt.Printf("%15s: %s\n", "Alphabet", alphabetLet)
+		if alphabetInner != alphabetLet {
+			fmt.Printf("%15s: %s\n", "Inner (mixed)", alphabetInner)
+		}
 		if flgDual {
 			fmt.Printf("%15s: %s\n", "Symbols ", alphabetPun)
 		}
@@ -40,6 +40,19 @@ var (
 	BoundCipherModeName binding.ExternalString = binding.BindString(&DataBindings.modeName)
 	// the Use PDU checkbox
 	BoundOptionUsePDU binding.ExternalBool = binding.BindBool(&DataBindings.optUsePDU)
+	// the Count All Characters (keying policy) checkbox
+	BoundOptionCountAll binding.ExternalBool = binding.BindBool(&DataBindings.optCountAll)
+	// the Normalize Text (classic 5-letter groups) checkbox
+	BoundOptionNormalize binding.ExternalBool = binding.BindBool(&DataBindings.optNormalize)
+	// the Dual Disk (encrypt digits & punctuation) checkbox
+	BoundOptionDualDisk binding.ExternalBool = binding.BindBool(&DataBindings.optDualDisk)
+	// the Mixed Alphabet (keyword) checkbox
+	BoundOptionMixed binding.ExternalBool = binding.BindBool(&DataBindings.optMixed)
+	// the Keyword entry: Vigenère keyword, Autokey primer, Xorshift
+	// passphrase or the keyword of the mixed alphabet
+	BoundKeyword binding.ExternalString = binding.BindString(&DataBindings.keyword)
+	// the Multiplier entry of Affine
+	BoundMultiplier binding.ExternalInt = binding.BindInt(&DataBindings.multiplier)
 )
 
 /* ----------------------------------------------------------------
@@ -59,12 +72,18 @@ var (
 // the bound values in their natural form.
 type dataBinder struct {
 	// only for ExternalBind
-	alphaName string
-	keyShift  float64
-	keyOffset float64
-	modeName  string
-
-	optUsePDU bool
+	alphaName  string
+	keyShift   float64
+	keyOffset  float64
+	modeName   string
+	keyword    string
+	multiplier int
+
+	optUsePDU    bool
+	optCountAll  bool
+	optNormalize bool
+	optDualDisk  bool
+	optMixed     bool
 }
 
 /* ----------------------------------------------------------------
@@ -87,8 +106,14 @@ func (cp *dataBinder) Init() {
 	cp.keyShift = 0
 	cp.keyOffset = 0
 	cp.modeName = crypto.CaesarMode.String()
+	cp.keyword = ""
+	cp.multiplier = 1
 	// application options
 	cp.optUsePDU = false
+	cp.optCountAll = false
+	cp.optNormalize = false
+	cp.optDualDisk = false
+	cp.optMixed = false
 }
 
 // Binds the global bound data to listeners
@@ -116,7 +141,13 @@ func (cp *dataBinder) Reload() {
 	BoundKeyOffset.Reload()
 	BoundAlphaName.Reload()
 	BoundCipherModeName.Reload()
+	BoundKeyword.Reload()
+	BoundMultiplier.Reload()
 	BoundOptionUsePDU.Reload()
+	BoundOptionCountAll.Reload()
+	BoundOptionNormalize.Reload()
+	BoundOptionDualDisk.Reload()
+	BoundOptionMixed.Reload()
 }
 
 // get a session model based on the bound data that is directly modified
@@ -183,3 +214,65 @@ func (cp *dataBinder) onChangedCipherMode() {
 /* ----------------------------------------------------------------
  *					F u n c t i o n s
  *-----------------------------------------------------------------*/
+
+// the keying policy selected by the Count All Characters option
+func boundKeyingPolicy() crypto.KeyingPolicy {
+	if countAll, _ := BoundOptionCountAll.Get(); countAll {
+		return crypto.CountAllKeying
+	}
+	return crypto.SkipForeignKeying
+}
+
+// the plain text normalization selected by the Normalize Text option
+func boundNormalization() crypto.NormalizerOptions {
+	if normalize, _ := BoundOptionNormalize.Get(); normalize {
+		return crypto.ClassicNormalization
+	}
+	return crypto.NormalizerOptions{}
+}
+
+// the paired symbols of the dual disk when the Dual Disk option is
+// selected and the alphabet has one (English & Spanish), else nil.
+func boundSymbolAlphabet(alpha *caesardisk.AlphabetModel) *caesardisk.AlphabetModel {
+	if dual, _ := BoundOptionDualDisk.Get(); dual {
+		return caesardisk.DualSymbolsFor(alpha)
+	}
+	return nil
+}
+
+// the keyword of the mixed cipher ring when the Mixed Alphabet option
+// is selected, else none so the plain alphabet is used.
+func boundMixKeyword() string {
+	if mixed, _ := BoundOptionMixed.Get(); mixed {
+		keyword, _ := BoundKeyword.Get()
+		return keyword
+	}
+	return ""
+}
+
+// the extra arguments of Encrypt() & Decrypt() for the session's cipher
+// mode, in the order of the parameters of its registered spec. They
+// end at the first parameter the GUI has no value for.
+func boundCipherArgs(sm crypto.SessionModel) []any {
+	spec, err := crypto.LookupCipherMode(sm.Mode)
+	if err != nil {
+		return nil
+	}
+
+	keyword, _ := BoundKeyword.Get()
+	multiplier, _ := BoundMultiplier.Get()
+	var args []any
+	for _, param := range spec.Params {
+		switch {
+		case param == crypto.OffsetParam:
+			args = append(args, sm.Offset)
+		case param == crypto.MultiplierParam:
+			args = append(args, multiplier)
+		case (param == crypto.KeywordParam || param == crypto.PassphraseParam) && len(keyword) != 0:
+			args = append(args, keyword)
+		default:
+			return args
+		}
+	}
+	return args
+}
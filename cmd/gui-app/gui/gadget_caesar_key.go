@@ -41,8 +41,9 @@ type CaesarKeyGadget struct {
 	labelD    *widget.Label
 	slider    *widget.Slider
 	//slider   *ScrollableSlider
-	alphabet *caesardisk.AlphabetModel
-	mutex    sync.Mutex
+	passphrase *widget.Entry // alternative to the slider
+	alphabet   *caesardisk.AlphabetModel
+	mutex      sync.Mutex
 }
 
 /* ----------------------------------------------------------------
@@ -83,11 +84,19 @@ func (g *CaesarKeyGadget) Define() IGadget {
 	slider := widget.NewSliderWithData(0, float64(sm.Alpha.Length()-1), BoundKeyShift)
 	//slider := NewScrollableSliderWidthData(0, float64(sm.Alpha.Length()-1), BoundKeyShift)
 	slider.Step = 1
+	// · Alternatively the keys are derived from a passphrase
+	passphrase := widget.NewPasswordEntry()
+	passphrase.SetPlaceHolder("or derive keys from passphrase")
+	deriveButton := widget.NewButton("Derive", func() {
+		g.onPassphrase(passphrase.Text)
+	})
+	passphraseRow := container.NewBorder(nil, nil, nil, deriveButton, passphrase)
 	// · Arrange widgets in a container with a proper layout
-	g.container = container.NewBorder(nil, nil, labelAlpha, labelShift, slider)
+	g.container = container.NewBorder(nil, passphraseRow, labelAlpha, labelShift, slider)
 	g.labelL = labelAlpha
 	g.labelD = labelShift
 	g.slider = slider
+	g.passphrase = passphrase
 
 	return g
 }
@@ -103,6 +112,7 @@ func (g *CaesarKeyGadget) Bind() IGadget {
 func (g *CaesarKeyGadget) PostRender() IGadget {
 	g.slider.OnChanged = g.changeCallback    // updates letter
 	g.slider.OnChangeEnded = g.endedCallback // regenerate disk
+	g.passphrase.OnSubmitted = g.onPassphrase
 
 	return g
 }
@@ -210,6 +220,28 @@ func (g *CaesarKeyGadget) endedCallback(finalSliderValue float64) {
 	g.parent.Cascade(GadgetMainKey, int(finalSliderValue))
 }
 
+// derives main key, offset and mixed alphabet from the passphrase, as
+// if the user had moved both sliders and typed the keyword. The main
+// GUI fits the offset to the current mode.
+func (g *CaesarKeyGadget) onPassphrase(passphrase string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	logx.OnChanged()
+
+	sm := DataBindings.GetSessionModel()
+	derived, err := crypto.DeriveKeyFromPassphrase(passphrase, &sm.Alpha)
+	if err != nil {
+		logx.Print(err)
+		return
+	}
+
+	BoundKeyShift.Set(float64(derived.MainKey.Shift))
+
+	g.updateVisualOnly(derived.MainKey.Letter, derived.MainKey.Shift, false)
+	g.parent.Cascade(GadgetMainKey, derived)
+}
+
 func (g *CaesarKeyGadget) updateVisualOnly(let rune, shift int, labelsOnly bool) {
 	if !labelsOnly {
 		g.slider.Value = float64(shift)
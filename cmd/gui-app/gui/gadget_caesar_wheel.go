@@ -73,6 +73,7 @@ type lastParameters struct {
 	Alpha       string
 	KeyShift    int
 	OffsetShift int
+	Reversed    bool
 }
 
 /* ----------------------------------------------------------------
@@ -261,6 +262,7 @@ func (g *WheelGadget) updateVisual() {
 		Alpha:       reqAlphaChars,
 		KeyShift:    reqKeyShift,
 		OffsetShift: sm.Offset,
+		Reversed:    sm.Mode.IsReversed(),
 	}
 	// · only do work if there has been a change
 	if g.last.Equal(reqValues) {
@@ -272,13 +274,16 @@ func (g *WheelGadget) updateVisual() {
 	const GENERATE_DUAL_ALPHABET_DISK bool = false
 	var imgBase, imgOverlay, imgComposite image.Image
 	var err error = nil
+	// · Atbash disks have a counter-clockwise inner disk
+	innerOpts := *g.wheelOpts
+	innerOpts.Reversed = reqValues.Reversed
 
 	// base/outer
 	if imgBase, err = caesardisk.GenerateCaesarWheelImage(
 		reqAlphaChars, false, *g.wheelOpts); err == nil {
 		// overlay/inner
 		if imgOverlay, err = caesardisk.GenerateCaesarWheelImage(
-			reqAlphaChars, true, *g.wheelOpts); err == nil {
+			reqAlphaChars, true, innerOpts); err == nil {
 			if imgComposite, err = caesardisk.SuperimposeDisksByShiftImage(
 				reqKeyShift,
 				reqAlphaLen,
@@ -305,7 +310,8 @@ func (g *WheelGadget) updateVisual() {
 func (l lastParameters) Equal(other lastParameters) bool {
 	return strings.EqualFold(l.Alpha, other.Alpha) &&
 		l.KeyShift == other.KeyShift &&
-		l.OffsetShift == other.OffsetShift
+		l.OffsetShift == other.OffsetShift &&
+		l.Reversed == other.Reversed
 }
 
 /* ----------------------------------------------------------------
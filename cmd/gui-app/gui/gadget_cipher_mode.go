@@ -78,12 +78,7 @@ func (g *CipherModeGadget) Define() IGadget {
 	g.cipherLabel = widget.NewLabel("Cipher mode")
 
 	//g.cipherSelect = widget.NewSelect([]string{
-	g.cipherSelect = widget.NewSelectWithData([]string{
-		crypto.CaesarMode.String(),    // 0
-		crypto.DidimusMode.String(),   // 1
-		crypto.FibonacciMode.String(), // 2
-		crypto.PrimusMode.String()},   // 3
-		BoundCipherModeName)
+	g.cipherSelect = widget.NewSelectWithData(selectableCipherModes(), BoundCipherModeName)
 
 	g.cipherContainer = container.New(layout.NewBorderLayout(nil, nil, g.cipherLabel, nil),
 		g.cipherLabel,
@@ -108,7 +103,7 @@ func (g *CipherModeGadget) Bind() IGadget {
 // application window, but prior to run, we call PostRender() to
 // set widget values that may/will trigger onChange cascade events.
 func (g *CipherModeGadget) PostRender() IGadget {
-	g.cipherSelect.SetSelectedIndex(int(crypto.CaesarMode))
+	g.cipherSelect.SetSelected(crypto.CaesarMode.String())
 	g.cipherSelect.OnChanged = g.onChangeEnded
 
 	return g
@@ -132,7 +127,7 @@ func (g *CipherModeGadget) Update() {
 	var sm crypto.SessionModel
 	sm = DataBindings.GetSessionModel()
 
-	g.cipherSelect.SetSelectedIndex(int(sm.Mode))
+	g.cipherSelect.SetSelected(sm.Mode.String())
 }
 
 // Hide gadget
@@ -162,12 +157,10 @@ func (g *CipherModeGadget) Clear() {
 
 // implement ICipherModeService
 func (g *CipherModeGadget) GetCipherMode() crypto.CaesarCipherMode {
-	var value crypto.CaesarCipherMode
-	selected := g.cipherSelect.SelectedIndex()
-	if selected > -1 {
-		value = crypto.CaesarCipherMode(selected)
-	} else {
-		logx.Print("invalid selectedIndex at CipherModeGadget. using default")
+	// the selector entries are not in CaesarCipherMode order
+	value, err := crypto.ParseCipherMode(g.cipherSelect.Selected)
+	if err != nil {
+		logx.Print("invalid selection at CipherModeGadget. using default")
 		value = crypto.CaesarMode
 	}
 
@@ -192,3 +185,28 @@ func (g *CipherModeGadget) onChangeEnded(s string) {
 /* ----------------------------------------------------------------
  *					F u n c t i o n s
  *-----------------------------------------------------------------*/
+
+// the names of the registered cipher modes that the GUI can drive, i.e.
+// those whose mandatory arguments have a widget: the offset slider, the
+// keyword entry or the multiplier entry.
+func selectableCipherModes() []string {
+	names := make([]string, 0)
+	for _, mode := range crypto.CipherModes() {
+		if spec, err := crypto.LookupCipherMode(mode); err == nil && hasWidgetsFor(spec) {
+			names = append(names, spec.Name)
+		}
+	}
+	return names
+}
+
+// whether every mandatory argument of the mode has a widget
+func hasWidgetsFor(spec crypto.CipherModeSpec) bool {
+	for _, param := range spec.Params[:spec.Required] {
+		switch param {
+		case crypto.OffsetParam, crypto.MultiplierParam, crypto.KeywordParam, crypto.PassphraseParam:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -197,6 +197,19 @@ func (g *KeyOffsetGadget) SetOffset(altKeyLetter rune, altShift int) {
 	g.slider.Value = float64(altShift)
 }
 
+// sets the upper limit of the slider, e.g. the alphabet length for
+// Didimus or the number of prime terms for Primus.
+func (g *KeyOffsetGadget) SetMaximum(max int) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.slider.Max = float64(max)
+	if g.slider.Value > g.slider.Max {
+		g.slider.Value = 0
+	}
+	g.slider.Refresh()
+}
+
 // resets the control's view to a slider value of zero
 func (g *KeyOffsetGadget) Reset() {
 	g.mutex.Lock()
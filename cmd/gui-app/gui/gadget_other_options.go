@@ -10,6 +10,7 @@ package gui
 import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/widget"
 	"github.com/lordofscripts/caesardisk"
 )
@@ -31,9 +32,17 @@ var _ IGadget = (*MiscOptionsGadget)(nil)
 type MiscOptionsGadget struct {
 	parent IGadgetParent
 	// Params/Options tab
-	checkOrtho *widget.Check
-	checkPDU   *widget.Check
-	card       *widget.Card
+	checkOrtho    *widget.Check
+	checkPDU      *widget.Check
+	checkCountAll *widget.Check
+	checkNormal   *widget.Check
+	checkDual     *widget.Check
+	checkMixed    *widget.Check
+	entryKeyword  *widget.Entry
+	labelMult     *widget.Label
+	entryMult     *widget.Entry
+	multEditable  bool
+	card          *widget.Card
 
 	wheelOpts *caesardisk.CaesarWheelOptions
 }
@@ -63,10 +72,32 @@ func (g *MiscOptionsGadget) Define() IGadget {
 	}
 
 	g.checkPDU = widget.NewCheckWithData("Use PDU format", BoundOptionUsePDU)
+	// keys advance on every character rather than only on letters
+	g.checkCountAll = widget.NewCheckWithData("Count all characters", BoundOptionCountAll)
+	// no accents, spaces nor punctuation & 5-letter groups
+	g.checkNormal = widget.NewCheckWithData("Normalize text (5-letter groups)", BoundOptionNormalize)
+	// digits & punctuation with the paired ring (English & Español)
+	g.checkDual = widget.NewCheckWithData("Dual disk (English & Español)", BoundOptionDualDisk)
+	// the cipher ring is mixed with the keyword
+	g.checkMixed = widget.NewCheckWithData("Mixed alphabet (keyword)", BoundOptionMixed)
+	// the Vigenère keyword, Autokey primer, Xorshift passphrase or
+	// mixing keyword
+	g.entryKeyword = widget.NewEntryWithData(BoundKeyword)
+	g.entryKeyword.SetPlaceHolder("Keyword / passphrase")
+	// the Affine multiplier, coprime with the alphabet length
+	g.labelMult = widget.NewLabel("Multiplier")
+	g.entryMult = widget.NewEntryWithData(binding.IntToString(BoundMultiplier))
+	g.entryMult.Disable()
 
 	miscCardContent := container.NewVBox(
 		g.checkOrtho,
 		g.checkPDU,
+		g.checkCountAll,
+		g.checkNormal,
+		g.checkDual,
+		g.checkMixed,
+		g.entryKeyword,
+		container.NewBorder(nil, nil, g.labelMult, nil, g.entryMult),
 	)
 
 	g.card = widget.NewCard(
@@ -120,18 +151,38 @@ func (g *MiscOptionsGadget) Show() {
 func (g *MiscOptionsGadget) Enable() {
 	g.checkOrtho.Enable()
 	g.checkPDU.Enable()
+	g.checkCountAll.Enable()
+	g.checkNormal.Enable()
+	g.checkDual.Enable()
+	g.checkMixed.Enable()
+	g.entryKeyword.Enable()
+	if g.multEditable {
+		g.entryMult.Enable()
+	}
 }
 
 // Disable gadget
 func (g *MiscOptionsGadget) Disable() {
 	g.checkOrtho.Disable()
 	g.checkPDU.Disable()
+	g.checkCountAll.Disable()
+	g.checkNormal.Disable()
+	g.checkDual.Disable()
+	g.checkMixed.Disable()
+	g.entryKeyword.Disable()
+	g.entryMult.Disable()
 }
 
 // Clears all fields of a gadget
 func (g *MiscOptionsGadget) Clear() {
 	g.checkOrtho.SetChecked(false)
 	g.checkPDU.SetChecked(false)
+	g.checkCountAll.SetChecked(false)
+	g.checkNormal.SetChecked(false)
+	g.checkDual.SetChecked(false)
+	g.checkMixed.SetChecked(false)
+	g.entryKeyword.SetText("")
+	g.entryMult.SetText("1")
 }
 
 func (g *MiscOptionsGadget) GetRenderOrthogonality() bool {
@@ -142,6 +193,28 @@ func (g *MiscOptionsGadget) GetOperUsePDU() bool {
 	return g.checkPDU.Checked
 }
 
+func (g *MiscOptionsGadget) GetOperCountAll() bool {
+	return g.checkCountAll.Checked
+}
+
+func (g *MiscOptionsGadget) GetOperNormalize() bool {
+	return g.checkNormal.Checked
+}
+
+func (g *MiscOptionsGadget) GetOperDualDisk() bool {
+	return g.checkDual.Checked
+}
+
+// the multiplier is only editable with a mode that uses it (Affine)
+func (g *MiscOptionsGadget) CanEditMultiplier(enabled bool) {
+	g.multEditable = enabled
+	if enabled {
+		g.entryMult.Enable()
+	} else {
+		g.entryMult.Disable()
+	}
+}
+
 /* ----------------------------------------------------------------
  *				P r i v a t e	M e t h o d s
  *-----------------------------------------------------------------*/
@@ -106,7 +106,8 @@ func (g *SecretDataGadget) Define() IGadget {
 	// · Encode : perform encryption in the selected mode and parameters
 	g.buttonEncode = widget.NewButton("Encode", g.onEncodeClicked)
 	g.buttonEncode.Disable()
-	// · Decode : perform decryption in the selected mode and parameters
+	// · Decode : perform decryption in the selected mode and parameters,
+	//   only of the selected region of the input when there is one.
 	g.buttonDecode = widget.NewButton("Decode", g.onDecodeClicked)
 	g.buttonDecode.Disable()
 
@@ -145,6 +146,11 @@ func (g *SecretDataGadget) Container() *fyne.Container {
 	return g.container
 }
 
+// the text of the input box
+func (g *SecretDataGadget) InputText() string {
+	return g.textEntry1.Text
+}
+
 // Update should be called if there are unbound data model values
 // that would require widget state to change.
 func (g *SecretDataGadget) Update() {
@@ -240,13 +246,13 @@ func (g *SecretDataGadget) onEncodeClicked() {
 	sm = DataBindings.GetSessionModel()
 	logx.Printf("Encode with %s", sm)
 
-	cipherC := g.engine.CloneWith(&sm.Alpha)
+	cipherC := g.engine.CloneWith(&sm.Alpha).
+		SetKeyingPolicy(boundKeyingPolicy()).
+		SetNormalization(boundNormalization()).
+		SetSymbolAlphabet(boundSymbolAlphabet(&sm.Alpha)).
+		SetMixedAlphabet(boundMixKeyword())
 	// · Encrypt operation
-	if sm.Mode != crypto.DidimusMode {
-		result, err = cipherC.Encrypt(sm.Mode, g.textEntry1.Text, sm.MainKey.Shift)
-	} else {
-		result, err = cipherC.Encrypt(sm.Mode, g.textEntry1.Text, sm.MainKey.Shift, sm.Offset)
-	}
+	result, err = cipherC.Encrypt(sm.Mode, g.textEntry1.Text, sm.MainKey.Shift, boundCipherArgs(sm)...)
 
 	if err != nil {
 		logx.Printf("Encrypt Error: %v", err)
@@ -276,11 +282,17 @@ func (g *SecretDataGadget) onDecodeClicked() {
 	var sm crypto.SessionModel
 	sm = DataBindings.GetSessionModel()
 	logx.Print(sm.String())
-	cipherC := g.engine.CloneWith(&sm.Alpha)
+	cipherC := g.engine.CloneWith(&sm.Alpha).
+		SetKeyingPolicy(boundKeyingPolicy()).
+		SetNormalization(boundNormalization()).
+		SetSymbolAlphabet(boundSymbolAlphabet(&sm.Alpha)).
+		SetMixedAlphabet(boundMixKeyword())
 
 	// · For PDUs we must unpack them first prior to Decrypting
-	if usePDU, _ := BoundOptionUsePDU.Get(); usePDU {
-		result, err = g.engine.UnpackMessage(g.textEntry1.Text, sm.Mode, sm.MainKey.Shift, sm.Offset)
+	args := boundCipherArgs(sm)
+	usePDU, _ := BoundOptionUsePDU.Get()
+	if usePDU {
+		result, err = cipherC.UnpackMessage(g.textEntry1.Text, sm.Mode, sm.MainKey.Shift, args...)
 		if err != nil {
 			logx.AttentionAlways("PDU-Unpack", err)
 			g.textEntry2.SetText(err.Error())
@@ -296,11 +308,11 @@ func (g *SecretDataGadget) onDecodeClicked() {
 	}
 
 	// · Decrypt operation
-	if sm.Mode != crypto.DidimusMode && sm.Mode != crypto.PrimusMode {
-		result, err = cipherC.Decrypt(sm.Mode, result, sm.MainKey.Shift)
+	if from, to, ok := selectedRange(g.textEntry1); ok && !usePDU {
+		// only the selected region of a longer message
+		result, err = cipherC.DecryptFragment(sm.Mode, result, from, to, sm.MainKey.Shift, args...)
 	} else {
-		// Didimus & Primus use Offset value
-		result, err = cipherC.Decrypt(sm.Mode, result, sm.MainKey.Shift, sm.Offset)
+		result, err = cipherC.Decrypt(sm.Mode, result, sm.MainKey.Shift, args...)
 	}
 
 	if err != nil {
@@ -318,3 +330,24 @@ func (g *SecretDataGadget) onDecodeClicked() {
 /* ----------------------------------------------------------------
  *					F u n c t i o n s
  *-----------------------------------------------------------------*/
+
+// the rune offsets [from,to) of the text selected in the entry. The
+// cursor is at either end of the selection.
+func selectedRange(entry *widget.Entry) (from, to int, ok bool) {
+	selected := entry.SelectedText()
+	if len(selected) == 0 {
+		return 0, 0, false
+	}
+
+	text := []rune(entry.Text)
+	cursor := entry.CursorTextOffset()
+	length := len([]rune(selected))
+	if cursor-length >= 0 && string(text[cursor-length:cursor]) == selected {
+		return cursor - length, cursor, true
+	}
+	if cursor+length <= len(text) && string(text[cursor:cursor+length]) == selected {
+		return cursor, cursor + length, true
+	}
+
+	return 0, 0, false
+}
@@ -10,6 +10,7 @@ package gui
 import (
 	_ "embed"
 	"errors"
+	"fmt"
 	"strings"
 
 	"fyne.io/fyne/v2"
@@ -18,9 +19,10 @@ import (
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
 	"github.com/lordofscripts/caesardisk"
+	"github.com/lordofscripts/caesardisk/cryptanalysis"
 	"github.com/lordofscripts/caesardisk/crypto"
-	"github.com/lordofscripts/caesardisk/internal/cipher"
 	"github.com/lordofscripts/goapp/app/logx"
 	"github.com/lordofscripts/gofynex/fynex"
 	"github.com/lordofscripts/gofynex/fynex/dlg"
@@ -42,18 +44,15 @@ const (
 	InitialCipherMode = crypto.CaesarMode
 )
 
-// content for the About dialog
+// content for the About dialog, the list of cipher modes is that of
+// the cipher mode selector.
 const aboutCONTENT string = `
 ## CaesarDisk GUI
 A graphical user interface that integrates the
 *CaesarDisk* disk generator application. As a
 plus, you can also use various modes of the 
 Caesar cipher:
-* Caesar
-* Didimus
-* Fibonacci
-* Primus
-
+%s
 **CaesarDisk** and **CaesarDiskGUI** are
 Copyright ©2025 LordOfScripts™
 `
@@ -155,7 +154,7 @@ func (g *MainGUI) Define() *MainGUI {
 	// · Help|About dialog
 	me := fynex.NewPersonWithImage("Lord of Scripts™", "BScEE, Developer, Writer", developerIcon)
 	g.dlgAbout = dlg.NewAboutBox(myWindow, applicationIcon, meta).
-		WithText(aboutCONTENT, true, false).
+		WithText(aboutModesContent(), true, false).
 		WithPersonModel(me)
 
 	// · Main Menu Bar
@@ -166,9 +165,11 @@ func (g *MainGUI) Define() *MainGUI {
 
 	menuTopFile := fyne.NewMenu("File", menuFileOpen, fyne.NewMenuItemSeparator(), menuFileQuit)
 
-	//   Misc menu: KeySchedule
+	//   Misc menu: KeySchedule, Statistics, Cryptanalysis
 	menuMiscSchedule := newMenuItemWithShortcut(fyne.KeyModifierAlt, fyne.KeyK, "Key schedule", g.showKeySchedule)
-	menuTopMisc := fyne.NewMenu("Misc", menuMiscSchedule)
+	menuMiscStats := newMenuItemWithShortcut(fyne.KeyModifierAlt, fyne.KeyS, "Statistics", g.showStatistics)
+	menuMiscBreak := newMenuItemWithShortcut(fyne.KeyModifierAlt, fyne.KeyB, "Break cipher", g.showCryptanalysis)
+	menuTopMisc := fyne.NewMenu("Misc", menuMiscSchedule, menuMiscStats, menuMiscBreak)
 
 	//   Help menu: About
 	menuHelpAbout := newMenuItemWithShortcut(fyne.KeyModifierAlt, fyne.KeyH, "About", g.dlgAbout.ShowDialog)
@@ -255,6 +256,23 @@ func (g *MainGUI) Cascade(serviceId GadgetId, value any) {
 		if v, ok := value.(int); ok {
 			logx.OnCascade("mainKey", v)
 
+			// the alternate key depends on the main key
+			g.gadgets.Offset.Update()
+			g.gadgets.Wheel.Update()
+		}
+		if v, ok := value.(crypto.PassphraseKey); ok {
+			logx.OnCascade("passphrase", v.MainKey)
+
+			// the derived offset must fit the slider of the mode,
+			// e.g. Primus Offset is the number of prime terms
+			sm := DataBindings.GetSessionModel()
+			_, maxOffset := g.controllers.Cipher.CloneWith(&sm.Alpha).OffsetRange(sm.Mode)
+			BoundKeyOffset.Set(float64(min(v.Offset, maxOffset)))
+			// and the passphrase keyword mixes the cipher ring
+			BoundKeyword.Set(v.MixKeyword)
+			BoundOptionMixed.Set(true)
+
+			g.gadgets.Offset.Update()
 			g.gadgets.Wheel.Update()
 		}
 
@@ -277,14 +295,22 @@ func (g *MainGUI) Cascade(serviceId GadgetId, value any) {
 	case GadgetCipherMode:
 		if v, ok := value.(crypto.CaesarCipherMode); ok {
 			logx.OnCascade("cipherMode", v)
-			if v == crypto.DidimusMode || v == crypto.PrimusMode {
+			if v.UsesOffset() {
 				BoundKeyOffset.Set(0)
+				// e.g. Primus Offset is the number of prime terms
+				sm := DataBindings.GetSessionModel()
+				_, maxOffset := g.controllers.Cipher.CloneWith(&sm.Alpha).OffsetRange(v)
+				g.gadgets.Offset.SetMaximum(maxOffset)
 				g.gadgets.Offset.Show()
 				g.gadgets.Wheel.CanShowOffset(true)
 			} else {
 				g.gadgets.Wheel.CanShowOffset(false)
 				g.gadgets.Offset.Hide()
 			}
+			// the Affine multiplier entry is only used by Affine
+			g.gadgets.Misc.CanEditMultiplier(v.Uses(crypto.MultiplierParam))
+			// e.g. Atbash uses a counter-clockwise inner disk
+			g.gadgets.Wheel.Update()
 		}
 
 	case GadgetOtherOpts:
@@ -380,34 +406,20 @@ func (g *MainGUI) showKeySchedule() {
 	if alphaName, err := BoundAlphaName.Get(); err == nil {
 		alpha := caesardisk.AlphabetFactory(alphaName)
 		// cipher controller with currently selected alphabet
-		ctrl := g.controllers.Cipher.CloneWith(alpha)
-		// current encryption parameters
-		sm := DataBindings.GetSessionModel()
-		param := cipher.CaesarParameters{
-			//Alphabet: alpha,
-			KeyValue: sm.MainKey.Shift,
-			Offset:   sm.Offset,
-		}
+		ctrl := g.controllers.Cipher.CloneWith(alpha).
+			SetKeyingPolicy(boundKeyingPolicy()).
+			SetMixedAlphabet(boundMixKeyword())
 		// get programmed key schedule for current key/offset setting
-		var err error
-		var schedule crypto.KeySchedule
-		switch g.gadgets.Cipher.GetCipherMode() { // @note Update when new cipher modes
-		case crypto.CaesarMode:
-			schedule, err = ctrl.GetCaesarSchedule(param.KeyValue)
-		case crypto.DidimusMode:
-			schedule, err = ctrl.GetDidimusSchedule(param.KeyValue, param.Offset)
-		case crypto.FibonacciMode:
-			schedule, err = ctrl.GetFibonacciSchedule(param.KeyValue)
-		case crypto.PrimusMode:
-			schedule, err = ctrl.GetPrimusSchedule(param.KeyValue, param.Offset)
-		}
+		sm := DataBindings.GetSessionModel()
+		sm.Mode = g.gadgets.Cipher.GetCipherMode()
+		schedule, err := ctrl.GetKeySchedule(sm.Mode, sm.MainKey.Shift, boundCipherArgs(sm)...)
 		// any errors?
 		if err != nil {
 			g.Notify(true, err.Error())
 		} else {
 			// show schedule to user
 			title, _ := BoundCipherModeName.Get()
-			modal := NewKeyScheduleViewer(g.w, title, schedule)
+			modal := NewKeyScheduleViewer(g.w, title, schedule, ctrl.KeyingPolicy())
 			modal.Resize(fyne.NewSize(WINDOW_WIDTH+50, WINDOW_HEIGHT/2))
 			modal.Show()
 		}
@@ -417,6 +429,97 @@ func (g *MainGUI) showKeySchedule() {
 
 }
 
+// shows the statistics of the text of the input box over the selected
+// alphabet.
+func (g *MainGUI) showStatistics() {
+	alphaName, err := BoundAlphaName.Get()
+	if err != nil {
+		logx.Print(err)
+		return
+	}
+	text := g.gadgets.Data.InputText()
+	if len(strings.TrimSpace(text)) == 0 {
+		g.Notify(true, "Enter the text to analyze")
+		return
+	}
+
+	modal := NewStatisticsViewer(g.w, text, caesardisk.AlphabetFactory(alphaName), boundKeyingPolicy())
+	modal.Resize(fyne.NewSize(WINDOW_WIDTH+50, WINDOW_HEIGHT*2/3))
+	modal.Show()
+}
+
+// identifies the cipher mode of the ciphertext of the input box, then
+// tries to break it with the attack on the selected cipher mode and
+// shows the findings.
+func (g *MainGUI) showCryptanalysis() {
+	alphaName, err := BoundAlphaName.Get()
+	if err != nil {
+		logx.Print(err)
+		return
+	}
+	alpha := caesardisk.AlphabetFactory(alphaName)
+	ciphered := g.gadgets.Data.InputText()
+	if len(strings.TrimSpace(ciphered)) == 0 {
+		g.Notify(true, "Enter the ciphertext to break")
+		return
+	}
+
+	var report strings.Builder
+	if identifier, err := cryptanalysis.NewModeIdentifier(alpha); err == nil {
+		identifier.SetKeyingPolicy(boundKeyingPolicy()).SetPrimeWindow(g.controllers.Cipher.PrimeWindow())
+		if guess, err := identifier.Identify(ciphered); err == nil {
+			fmt.Fprintf(&report, "Looks like %s\n\n", guess)
+		}
+	}
+
+	mode := g.gadgets.Cipher.GetCipherMode()
+	switch mode {
+	case crypto.CaesarMode:
+		var solver *cryptanalysis.CaesarSolver
+		var candidates []cryptanalysis.Candidate
+		if solver, err = cryptanalysis.NewCaesarSolver(alpha); err == nil {
+			candidates, err = solver.Solve(ciphered)
+		}
+		// the most plausible candidates
+		for i := 0; i < len(candidates) && i < 5; i++ {
+			fmt.Fprintf(&report, "#%d %s\n", i+1, candidates[i])
+		}
+	case crypto.DidimusMode:
+		var solver *cryptanalysis.DidimusSolver
+		var key cryptanalysis.DidimusKey
+		if solver, err = cryptanalysis.NewDidimusSolver(alpha); err == nil {
+			key, err = solver.SetKeyingPolicy(boundKeyingPolicy()).Solve(ciphered)
+		}
+		report.WriteString(key.String())
+	case crypto.FibonacciMode, crypto.PrimusMode:
+		var solver *cryptanalysis.PeriodicSolver
+		var candidates []cryptanalysis.ModeCandidate
+		if solver, err = cryptanalysis.NewPeriodicSolver(alpha); err == nil {
+			solver.SetKeyingPolicy(boundKeyingPolicy()).SetPrimeWindow(g.controllers.Cipher.PrimeWindow())
+			if mode == crypto.FibonacciMode {
+				candidates, err = solver.SolveFibonacci(ciphered)
+			} else {
+				candidates, err = solver.SolvePrimus(ciphered)
+			}
+		}
+		for i := 0; i < len(candidates) && i < 5; i++ {
+			fmt.Fprintf(&report, "#%d %s\n", i+1, candidates[i])
+		}
+	default:
+		fmt.Fprintf(&report, "There is no attack on %s yet", mode)
+	}
+	if err != nil {
+		g.Notify(true, err.Error())
+		return
+	}
+
+	findings := widget.NewLabelWithStyle(report.String(), fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	findings.Wrapping = fyne.TextWrapWord
+	modal := dialog.NewCustom(mode.String()+" Cryptanalysis", "OK", container.NewVScroll(findings), g.w)
+	modal.Resize(fyne.NewSize(WINDOW_WIDTH+50, WINDOW_HEIGHT/2))
+	modal.Show()
+}
+
 // call Bind on all gadgets
 func (ag *appGadgets) Bind() {
 	ag.Alpha.Bind()
@@ -432,6 +535,15 @@ func (ag *appGadgets) Bind() {
  *					F u n c t i o n s
  *-----------------------------------------------------------------*/
 
+// the About dialog content listing the selectable cipher modes
+func aboutModesContent() string {
+	var modes strings.Builder
+	for _, name := range selectableCipherModes() {
+		modes.WriteString("* " + name + "\n")
+	}
+	return fmt.Sprintf(aboutCONTENT, modes.String())
+}
+
 // creates a menu item with a shortcut and action callback
 func newMenuItemWithShortcut(modifier fyne.KeyModifier, keyname fyne.KeyName, label string, callback MenuItemActionCB) *fyne.MenuItem {
 	shortcut := &desktop.CustomShortcut{
@@ -46,13 +46,14 @@ type KeyScheduleViewer struct {
  *				C o n s t r u c t o r s
  *-----------------------------------------------------------------*/
 
-// Create a modal editor but don't show it yet.
-func NewKeyScheduleViewer(w fyne.Window, title string, schedule crypto.KeySchedule) *KeyScheduleViewer {
+// Create a modal editor but don't show it yet. The keying policy tells
+// the user which characters of the message take the successive keys.
+func NewKeyScheduleViewer(w fyne.Window, title string, schedule crypto.KeySchedule, keying crypto.KeyingPolicy) *KeyScheduleViewer {
 	me := &KeyScheduleViewer{
 		isDismissed: false,
 		modal:       nil,
 	}
-	me.build(w, title, schedule)
+	me.build(w, title, schedule, keying)
 
 	return me
 }
@@ -99,7 +100,7 @@ func (ksv *KeyScheduleViewer) IsDismissed() bool {
 
 // build up the modal window containing the multi-line text box and
 // the Accept & Cancel buttons at the bottom.
-func (ksv *KeyScheduleViewer) build(w fyne.Window, title string, schedule crypto.KeySchedule) {
+func (ksv *KeyScheduleViewer) build(w fyne.Window, title string, schedule crypto.KeySchedule, keying crypto.KeyingPolicy) {
 	dataWidget := widget.NewTable(
 		// Table: Dimensions
 		func() (rows int, cols int) {
@@ -135,7 +136,12 @@ func (ksv *KeyScheduleViewer) build(w fyne.Window, title string, schedule crypto
 	dataWidget.SetColumnWidth(3, 100) // Comment
 
 	// 2. Wrap in a Max container so the table fills the dialog
-	editorContainer := container.NewStack(dataWidget)
+	keyingNote := "Keys advance only on alphabet letters"
+	if keying.KeysForeign() {
+		keyingNote = "Keys advance on every character (spaces & punctuation too)"
+	}
+	editorContainer := container.NewBorder(widget.NewLabel(keyingNote), nil, nil, nil,
+		container.NewStack(dataWidget))
 	ksv.modal = dialog.NewCustom(title+" Key Schedule", "OK", editorContainer, w)
 
 	// 3. MUST resize the dialog, otherwise it collapses to minimum size
@@ -0,0 +1,188 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *						goCaesarDisk GUI
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * A modal window with the statistics of a text over the selected
+ * alphabet: the letter frequency table, the index of coincidence and
+ * the key period estimates of Friedman & Kasiski.
+ *-----------------------------------------------------------------*/
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/lordofscripts/caesardisk"
+	"github.com/lordofscripts/caesardisk/cryptanalysis"
+	"github.com/lordofscripts/caesardisk/crypto"
+	"github.com/lordofscripts/gofynex/fynex"
+)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+const (
+	// the longest period of the Kasiski & periodic IoC statistics
+	statisticsMaxPeriod = 12
+	// the number of repeated trigrams listed
+	statisticsRepetitions = 5
+)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// StatisticsViewer shows the statistics of a text in a modal window.
+type StatisticsViewer struct {
+	isDismissed bool
+	modal       *dialog.CustomDialog
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// Create a statistics viewer but don't show it yet. The keying policy
+// tells which characters take key positions for the period estimates.
+func NewStatisticsViewer(w fyne.Window, text string, alpha *caesardisk.AlphabetModel, keying crypto.KeyingPolicy) *StatisticsViewer {
+	sv := &StatisticsViewer{
+		isDismissed: false,
+		modal:       nil,
+	}
+	sv.build(w, text, alpha, keying)
+
+	return sv
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+func (sv *StatisticsViewer) Resize(size fyne.Size) {
+	sv.modal.Resize(size)
+}
+
+// Show the statistics window if it has not been dismissed.
+func (sv *StatisticsViewer) Show() {
+	if !sv.isDismissed {
+		sv.modal.Show()
+	}
+}
+
+// Hide the statistics window if it has not been dismissed.
+func (sv *StatisticsViewer) Hide() {
+	if !sv.isDismissed {
+		sv.modal.Hide()
+	}
+}
+
+// Destroy the statistics window. The instance can no longer be shown.
+// It is ignored if it has already been dismissed.
+func (sv *StatisticsViewer) Dismiss() {
+	if !sv.isDismissed {
+		sv.modal.Dismiss()
+	}
+}
+
+// Check whether the statistics window has already been dismissed.
+// If true, don't call Show()
+func (sv *StatisticsViewer) IsDismissed() bool {
+	return sv.isDismissed
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// build up the modal window with the summary at the top and the
+// letter frequency table below.
+func (sv *StatisticsViewer) build(w fyne.Window, text string, alpha *caesardisk.AlphabetModel, keying crypto.KeyingPolicy) {
+	table := cryptanalysis.LetterFrequencyTable(text, alpha)
+	highest := 0.0
+	for _, item := range table {
+		highest = max(highest, item.Frequency)
+	}
+
+	dataWidget := widget.NewTable(
+		// Table: Dimensions
+		func() (rows int, cols int) {
+			return len(table), 4
+		},
+		// Table: Create Cell
+		func() fyne.CanvasObject {
+			dlabl := fynex.NewDynamicLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{
+				Monospace: true,
+			}, nil)
+			return dlabl
+		},
+		// Table: Populate
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			label := o.(*fynex.DynamicLabel)
+			item := table[id.Row]
+			switch id.Col {
+			case 0:
+				label.SetText(string(item.Letter))
+			case 1:
+				label.SetText(fmt.Sprintf("%3d", item.Count))
+			case 2:
+				label.SetText(fmt.Sprintf("%5.2f%%", item.Frequency*100))
+			case 3:
+				bar := 0
+				if highest > 0 {
+					bar = int(item.Frequency / highest * 30)
+				}
+				label.SetText(strings.Repeat("█", bar))
+			}
+		})
+
+	// 1. MUST set explicit column widths
+	dataWidget.SetColumnWidth(0, 25)  // Letter
+	dataWidget.SetColumnWidth(1, 40)  // Count
+	dataWidget.SetColumnWidth(2, 70)  // Frequency
+	dataWidget.SetColumnWidth(3, 300) // Bar
+
+	// 2. The summary above the table
+	summary := widget.NewLabelWithStyle(sv.summary(text, alpha, keying), fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	summary.Wrapping = fyne.TextWrapWord
+	statsContainer := container.NewBorder(summary, nil, nil, nil, container.NewStack(dataWidget))
+	sv.modal = dialog.NewCustom(alpha.Name+" Statistics", "OK", statsContainer, w)
+
+	// 3. MUST resize the dialog, otherwise it collapses to minimum size
+	sv.modal.Resize(fyne.NewSize(600, 500))
+}
+
+// the index of coincidence & the key period estimates as text
+func (sv *StatisticsViewer) summary(text string, alpha *caesardisk.AlphabetModel, keying crypto.KeyingPolicy) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "IoC: %.4f (random %.4f", cryptanalysis.IndexOfCoincidence(text, alpha), 1/float64(alpha.Length()))
+	if profile, err := cryptanalysis.LanguageProfileFor(alpha); err == nil {
+		fmt.Fprintf(&sb, ", %s %.4f)\n", profile.Name, profile.IndexOfCoincidence(alpha))
+		fmt.Fprintf(&sb, "Friedman period: %.1f\n", cryptanalysis.FriedmanPeriod(text, alpha, profile))
+	} else {
+		sb.WriteString(")\n")
+	}
+
+	sb.WriteString("IoC by period:")
+	for period := 1; period <= statisticsMaxPeriod; period++ {
+		fmt.Fprintf(&sb, " %d:%.3f", period, cryptanalysis.PeriodicIndexOfCoincidence(text, alpha, keying, period))
+	}
+
+	repetitions := cryptanalysis.KasiskiRepetitions(text, alpha, keying)
+	factors := cryptanalysis.KasiskiFactors(repetitions, statisticsMaxPeriod)
+	sb.WriteString("\nKasiski factors:")
+	for period := 2; period <= statisticsMaxPeriod; period++ {
+		fmt.Fprintf(&sb, " %d:%d", period, factors[period])
+	}
+	sb.WriteString("\nRepeated trigrams:")
+	for i := 0; i < len(repetitions) && i < statisticsRepetitions; i++ {
+		fmt.Fprintf(&sb, " %s%v", repetitions[i].Sequence, repetitions[i].Distances())
+	}
+
+	return sb.String()
+}
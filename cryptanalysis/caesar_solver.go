@@ -0,0 +1,126 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * A brute-force solver for the plain Caesar cipher. It decrypts the
+ * ciphertext with every key shift of the alphabet and ranks the
+ * candidate plaintexts by their chi-squared statistic against the
+ * letter frequencies of the language.
+ *
+ *	solver, _ := cryptanalysis.NewCaesarSolver(caesardisk.AlphabetFactory("EN"))
+ *	candidates, _ := solver.Solve("Wkh txlfn eurzq ira")
+ *	fmt.Println(candidates[0].Key, candidates[0].Plain)
+ *-----------------------------------------------------------------*/
+package cryptanalysis
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/lordofscripts/caesardisk"
+	"github.com/lordofscripts/caesardisk/engine"
+)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// A candidate decryption of a brute-force attack
+type Candidate struct {
+	Key        int  // the key shift that was tried
+	KeyChar    rune // the letter of the key shift
+	Plain      string
+	ChiSquared float64 // the lower the better
+}
+
+// Breaks plain Caesar ciphertexts of an alphabet by exhaustive search
+type CaesarSolver struct {
+	alpha   *caesardisk.AlphabetModel
+	profile *LanguageProfile
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// (ctor) a Caesar solver for a built-in alphabet, which is scored with
+// the letter frequencies of its language.
+func NewCaesarSolver(alpha *caesardisk.AlphabetModel) (*CaesarSolver, error) {
+	profile, err := LanguageProfileFor(alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCaesarSolverWithProfile(alpha, profile), nil
+}
+
+// (ctor) a Caesar solver for any alphabet scored with the given
+// language profile.
+func NewCaesarSolverWithProfile(alpha *caesardisk.AlphabetModel, profile *LanguageProfile) *CaesarSolver {
+	return &CaesarSolver{
+		alpha:   alpha,
+		profile: profile,
+	}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// implements fmt.Stringer
+func (c Candidate) String() string {
+	return fmt.Sprintf("%c|%02d χ²=%.2f %s", c.KeyChar, c.Key, c.ChiSquared, c.Plain)
+}
+
+// the language profile used for scoring
+func (cs *CaesarSolver) Profile() *LanguageProfile {
+	return cs.profile
+}
+
+// tries every key shift in KeyRange() and returns all the candidates
+// ranked from the most to the least plausible plaintext.
+func (cs *CaesarSolver) Solve(ciphered string) ([]Candidate, error) {
+	probe, err := engine.NewSequencer(engine.CaesarMode, cs.alpha, engine.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	min, max := probe.KeyRange()
+	candidates := make([]Candidate, 0, max-min+1)
+	for key := min; key <= max; key++ {
+		caesar, err := engine.New(engine.CaesarMode, cs.alpha, engine.Options{Key: key})
+		if err != nil {
+			return nil, err
+		}
+
+		plain := caesar.Decode(ciphered)
+		keyChar, _ := cs.alpha.Character(key)
+		candidates = append(candidates, Candidate{
+			Key:        key,
+			KeyChar:    keyChar,
+			Plain:      plain,
+			ChiSquared: cs.profile.ChiSquared(plain, cs.alpha),
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].ChiSquared < candidates[j].ChiSquared
+	})
+
+	return candidates, nil
+}
+
+// the most plausible decryption of the ciphertext
+func (cs *CaesarSolver) Best(ciphered string) (Candidate, error) {
+	candidates, err := cs.Solve(ciphered)
+	if err != nil {
+		return Candidate{}, err
+	}
+	if len(candidates) == 0 {
+		return Candidate{}, errors.New("no candidate keys")
+	}
+
+	return candidates[0], nil
+}
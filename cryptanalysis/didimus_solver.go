@@ -0,0 +1,157 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * A key recovery attack on Didimus. Didimus encrypts the even
+ * encodeable characters with the main key and the odd ones with the
+ * alternate key, therefore the ciphertext is just two interleaved
+ * Caesar ciphertexts that fall independently to the Caesar solver.
+ * The work factor is twice that of plain Caesar, not its square.
+ *-----------------------------------------------------------------*/
+package cryptanalysis
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lordofscripts/caesardisk"
+	"github.com/lordofscripts/caesardisk/engine"
+	"github.com/lordofscripts/caesardisk/internal/cipher"
+)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// The Didimus key recovered from a ciphertext
+type DidimusKey struct {
+	Main     int // key shift of the even characters
+	Offset   int // Alt = Main + Offset (modulo N)
+	Alt      int // key shift of the odd characters
+	MainChar rune
+	AltChar  rune
+	// the decryption with the recovered key & its score
+	Plain      string
+	ChiSquared float64
+}
+
+// Recovers the main key & offset of Didimus ciphertexts
+type DidimusSolver struct {
+	caesar *CaesarSolver
+	keying engine.KeyingPolicy
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// (ctor) a Didimus solver for a built-in alphabet, which is scored
+// with the letter frequencies of its language.
+func NewDidimusSolver(alpha *caesardisk.AlphabetModel) (*DidimusSolver, error) {
+	caesar, err := NewCaesarSolver(alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DidimusSolver{caesar: caesar}, nil
+}
+
+// (ctor) a Didimus solver for any alphabet scored with the given
+// language profile.
+func NewDidimusSolverWithProfile(alpha *caesardisk.AlphabetModel, profile *LanguageProfile) *DidimusSolver {
+	return &DidimusSolver{caesar: NewCaesarSolverWithProfile(alpha, profile)}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// implements fmt.Stringer
+func (dk DidimusKey) String() string {
+	return fmt.Sprintf("Didimus(%c|%d,%c|%d) Offset:%d χ²=%.2f %s",
+		dk.MainChar, dk.Main, dk.AltChar, dk.Alt, dk.Offset, dk.ChiSquared, dk.Plain)
+}
+
+// the keying policy the message was encrypted with, it determines
+// which characters alternate between the even & odd streams.
+func (ds *DidimusSolver) SetKeyingPolicy(policy engine.KeyingPolicy) *DidimusSolver {
+	ds.keying = policy
+	return ds
+}
+
+// recovers the most plausible main key & offset. The ciphertext must
+// have at least two encodeable characters.
+func (ds *DidimusSolver) Solve(ciphered string) (DidimusKey, error) {
+	alpha := ds.caesar.alpha
+	even, odd := ds.split(alpha.Normalize(ciphered))
+	if len(odd) == 0 {
+		return DidimusKey{}, errors.New("a Didimus ciphertext needs at least two letters")
+	}
+
+	// I. Each stream is a plain Caesar ciphertext
+	bestEven, err := ds.caesar.Best(even)
+	if err != nil {
+		return DidimusKey{}, err
+	}
+	bestOdd, err := ds.caesar.Best(odd)
+	if err != nil {
+		return DidimusKey{}, err
+	}
+
+	// II. The offset that yields the alternate key (DidimusCorrection)
+	N := alpha.Length()
+	offset := ((bestOdd.Key-bestEven.Key)%N + N) % N
+	main, offset, alt, _ := cipher.DidimusCorrection(bestEven.Key, offset, alpha)
+	if alt != bestOdd.Key {
+		return DidimusKey{}, fmt.Errorf("the odd characters are not encrypted (shift %d), not a Didimus ciphertext", bestOdd.Key)
+	}
+
+	// III. Decrypt the whole message with the recovered key
+	caesar, err := engine.New(engine.DidimusMode, alpha, engine.Options{Key: main, Offset: offset, Keying: ds.keying})
+	if err != nil {
+		return DidimusKey{}, err
+	}
+	plain := caesar.Decode(ciphered)
+
+	return DidimusKey{
+		Main:       main,
+		Offset:     offset,
+		Alt:        alt,
+		MainChar:   bestEven.KeyChar,
+		AltChar:    bestOdd.KeyChar,
+		Plain:      plain,
+		ChiSquared: ds.caesar.profile.ChiSquared(plain, alpha),
+	}, nil
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// splits the ciphertext into the characters encrypted with the main
+// key (even) and those with the alternate key (odd) exactly as the
+// DidimusSequencer toggles its keys.
+func (ds *DidimusSolver) split(ciphered string) (even, odd string) {
+	var sbEven, sbOdd strings.Builder
+	isEven := false
+	for _, char := range ciphered {
+		encodeable := ds.caesar.alpha.Find(char) != -1
+		if !encodeable && !ds.keying.KeysForeign() {
+			continue
+		}
+
+		isEven = !isEven // toggle, first (0) is even
+		if !encodeable {
+			continue
+		}
+		if isEven {
+			sbEven.WriteRune(char)
+		} else {
+			sbOdd.WriteRune(char)
+		}
+	}
+
+	return sbEven.String(), sbOdd.String()
+}
@@ -0,0 +1,218 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * The letter frequencies of the languages of the built-in alphabets.
+ * The figures are approximate percentages of published corpus counts
+ * and suffice to tell a plausible plaintext from a wrong decryption
+ * with the chi-squared statistic.
+ *-----------------------------------------------------------------*/
+package cryptanalysis
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/lordofscripts/caesardisk"
+)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+// the expected frequency (%) of an alphabet letter the profile lacks,
+// so that it never divides by zero.
+const frequencyFloor float64 = 0.01
+
+var (
+	English = &LanguageProfile{Code: "EN", Name: "English", Frequencies: LetterFrequencies{
+		'A': 8.167, 'B': 1.492, 'C': 2.782, 'D': 4.253, 'E': 12.702, 'F': 2.228,
+		'G': 2.015, 'H': 6.094, 'I': 6.966, 'J': 0.153, 'K': 0.772, 'L': 4.025,
+		'M': 2.406, 'N': 6.749, 'O': 7.507, 'P': 1.929, 'Q': 0.095, 'R': 5.987,
+		'S': 6.327, 'T': 9.056, 'U': 2.758, 'V': 0.978, 'W': 2.360, 'X': 0.150,
+		'Y': 1.974, 'Z': 0.074,
+	}}
+	Spanish = &LanguageProfile{Code: "ES", Name: "Español", Frequencies: LetterFrequencies{
+		'A': 11.525, 'B': 2.215, 'C': 4.019, 'D': 5.010, 'E': 12.181, 'F': 0.692,
+		'G': 1.768, 'H': 0.703, 'I': 6.247, 'J': 0.493, 'K': 0.011, 'L': 4.967,
+		'M': 3.157, 'N': 6.712, 'Ñ': 0.311, 'O': 8.683, 'P': 2.510, 'Q': 0.877,
+		'R': 6.871, 'S': 7.977, 'T': 4.632, 'U': 2.927, 'V': 1.138, 'W': 0.017,
+		'X': 0.215, 'Y': 1.008, 'Z': 0.467,
+		'Á': 0.502, 'É': 0.433, 'Í': 0.725, 'Ó': 0.827, 'Ú': 0.168,
+	}}
+	German = &LanguageProfile{Code: "DE", Name: "Deutsch", Frequencies: LetterFrequencies{
+		'A': 6.516, 'B': 1.886, 'C': 2.732, 'D': 5.076, 'E': 16.396, 'F': 1.656,
+		'G': 3.009, 'H': 4.577, 'I': 6.550, 'J': 0.268, 'K': 1.417, 'L': 3.437,
+		'M': 2.534, 'N': 9.776, 'O': 2.594, 'P': 0.670, 'Q': 0.018, 'R': 7.003,
+		'S': 7.270, 'T': 6.154, 'U': 4.166, 'V': 0.846, 'W': 1.921, 'X': 0.034,
+		'Y': 0.039, 'Z': 1.134,
+		'Ä': 0.578, 'Ö': 0.443, 'Ü': 0.995, 'ẞ': 0.307,
+	}}
+	Italian = &LanguageProfile{Code: "IT", Name: "Italiano", Frequencies: LetterFrequencies{
+		'A': 11.745, 'B': 0.927, 'C': 4.501, 'D': 3.736, 'E': 11.792, 'F': 1.153,
+		'G': 1.644, 'H': 0.636, 'I': 10.143, 'J': 0.011, 'K': 0.009, 'L': 6.510,
+		'M': 2.512, 'N': 6.883, 'O': 9.832, 'P': 3.056, 'Q': 0.505, 'R': 6.367,
+		'S': 4.981, 'T': 5.623, 'U': 3.011, 'V': 2.097, 'W': 0.033, 'X': 0.003,
+		'Y': 0.020, 'Z': 1.181,
+		'À': 0.635, 'È': 0.263, 'É': 0.030, 'Ì': 0.030, 'Ò': 0.002, 'Ó': 0.002, 'Ù': 0.166,
+	}}
+	Portuguese = &LanguageProfile{Code: "PT", Name: "Português", Frequencies: LetterFrequencies{
+		'A': 14.634, 'B': 1.043, 'C': 3.882, 'D': 4.992, 'E': 12.570, 'F': 1.023,
+		'G': 1.303, 'H': 0.781, 'I': 6.186, 'J': 0.397, 'K': 0.015, 'L': 2.779,
+		'M': 4.738, 'N': 4.446, 'O': 9.735, 'P': 2.523, 'Q': 1.204, 'R': 6.530,
+		'S': 6.805, 'T': 4.336, 'U': 3.639, 'V': 1.575, 'W': 0.037, 'X': 0.253,
+		'Y': 0.006, 'Z': 0.470,
+		'Á': 0.118, 'À': 0.072, 'Â': 0.562, 'Ã': 0.733, 'Ç': 0.530, 'É': 0.337,
+		'Ê': 0.450, 'Í': 0.132, 'Ó': 0.296, 'Ô': 0.635, 'Õ': 0.040, 'Ú': 0.207,
+	}}
+	Czech = &LanguageProfile{Code: "CZ", Name: "Czech", Frequencies: LetterFrequencies{
+		'A': 8.421, 'B': 0.822, 'C': 0.740, 'D': 3.475, 'E': 7.562, 'F': 0.084,
+		'G': 0.092, 'H': 1.356, 'I': 6.073, 'J': 1.433, 'K': 2.894, 'L': 3.802,
+		'M': 2.446, 'N': 6.468, 'O': 6.695, 'P': 1.906, 'Q': 0.001, 'R': 4.799,
+		'S': 5.212, 'T': 5.727, 'U': 2.160, 'V': 5.344, 'W': 0.016, 'X': 0.027,
+		'Y': 1.043, 'Z': 1.503,
+		'Á': 0.867, 'Č': 0.462, 'Ď': 0.015, 'É': 0.633, 'Ě': 1.222, 'Í': 1.643,
+		'Ň': 0.007, 'Ó': 0.024, 'Ř': 0.380, 'Š': 0.688, 'Ť': 0.006, 'Ú': 0.045,
+		'Ů': 0.204, 'Ý': 0.995, 'Ž': 0.721,
+	}}
+	Russian = &LanguageProfile{Code: "RU", Name: "Russian", Frequencies: LetterFrequencies{
+		'А': 8.01, 'Б': 1.59, 'В': 4.54, 'Г': 1.70, 'Д': 2.98, 'Е': 8.45,
+		'Ё': 0.04, 'Ж': 0.94, 'З': 1.65, 'И': 7.35, 'Й': 1.21, 'К': 3.49,
+		'Л': 4.40, 'М': 3.21, 'Н': 6.70, 'О': 10.97, 'П': 2.81, 'Р': 4.73,
+		'С': 5.47, 'Т': 6.26, 'У': 2.62, 'Ф': 0.26, 'Х': 0.97, 'Ц': 0.48,
+		'Ч': 1.44, 'Ш': 0.73, 'Щ': 0.36, 'Ъ': 0.04, 'Ы': 1.90, 'Ь': 1.74,
+		'Э': 0.32, 'Ю': 0.64, 'Я': 2.01,
+	}}
+	Greek = &LanguageProfile{Code: "GR", Name: "Greek", Frequencies: LetterFrequencies{
+		'Α': 11.55, 'Β': 0.88, 'Γ': 1.76, 'Δ': 1.85, 'Ε': 8.36, 'Ζ': 0.47,
+		'Η': 5.06, 'Θ': 1.32, 'Ι': 9.42, 'Κ': 4.21, 'Λ': 2.99, 'Μ': 3.44,
+		'Ν': 6.62, 'Ξ': 0.34, 'Ο': 9.06, 'Π': 4.31, 'Ρ': 4.48, 'Σ': 7.72,
+		'Τ': 8.42, 'Υ': 4.11, 'Φ': 0.78, 'Χ': 1.20, 'Ψ': 0.15, 'Ω': 1.55,
+	}}
+)
+
+// the language of each built-in alphabet (by AlphabetModel.Name)
+var alphabetLanguage map[string]*LanguageProfile = map[string]*LanguageProfile{
+	"English":             English,
+	"Español":             Spanish,
+	"Español con acentos": Spanish,
+	"Czech":               Czech,
+	"Deutsch":             German,
+	"Italiano":            Italian,
+	"Português":           Portuguese,
+	"Russian":             Russian,
+	"Greek":               Greek,
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// the frequency (%) of each (upper case) letter in a language
+type LetterFrequencies map[rune]float64
+
+// The letter frequencies of a natural language
+type LanguageProfile struct {
+	Code        string // as in caesardisk.AlphabetFactory()
+	Name        string
+	Frequencies LetterFrequencies
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// implements fmt.Stringer
+func (lp *LanguageProfile) String() string {
+	return fmt.Sprintf("%s (%s)", lp.Name, lp.Code)
+}
+
+// The chi-squared statistic of the letters of the text against the
+// profile. Only the letters of the alphabet are counted (regardless
+// of case), the lower the value the closer the text is to the
+// language. An empty text scores zero.
+func (lp *LanguageProfile) ChiSquared(text string, alpha *caesardisk.AlphabetModel) float64 {
+	counts := make([]int, alpha.Length())
+	total := 0
+	for _, char := range alpha.Normalize(text) {
+		if at := alpha.Find(char); at != -1 {
+			counts[at]++
+			total++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+
+	expected := lp.expected(alpha)
+	chi := 0.0
+	for i, observed := range counts {
+		e := expected[i] * float64(total)
+		d := float64(observed) - e
+		chi += d * d / e
+	}
+
+	return chi
+}
+
+// the index of coincidence of a text of the language written with the
+// alphabet, i.e. the sum of the squared letter probabilities.
+func (lp *LanguageProfile) IndexOfCoincidence(alpha *caesardisk.AlphabetModel) float64 {
+	ioc := 0.0
+	for _, p := range lp.expected(alpha) {
+		ioc += p * p
+	}
+	return ioc
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// the expected probability of each letter of the alphabet, i.e. the
+// profile restricted to the alphabet and scaled to add up to one.
+func (lp *LanguageProfile) expected(alpha *caesardisk.AlphabetModel) []float64 {
+	expected := make([]float64, alpha.Length())
+	sum := 0.0
+	for i, char := range []rune(alpha.String()) {
+		freq, ok := lp.Frequencies[unicode.ToUpper(char)]
+		if !ok || freq < frequencyFloor {
+			freq = frequencyFloor
+		}
+		expected[i] = freq
+		sum += freq
+	}
+	for i := range expected {
+		expected[i] /= sum
+	}
+
+	return expected
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+// the built-in language profiles
+func LanguageProfiles() []*LanguageProfile {
+	return []*LanguageProfile{English, Spanish, German, Italian, Portuguese, Czech, Russian, Greek}
+}
+
+// the language profile of a code, e.g. "EN"
+func LanguageProfileByCode(code string) (*LanguageProfile, error) {
+	for _, profile := range LanguageProfiles() {
+		if profile.Code == code {
+			return profile, nil
+		}
+	}
+	return nil, fmt.Errorf("no language profile for '%s'", code)
+}
+
+// the language profile of a built-in alphabet
+func LanguageProfileFor(alpha *caesardisk.AlphabetModel) (*LanguageProfile, error) {
+	if profile, ok := alphabetLanguage[alpha.Name]; ok {
+		return profile, nil
+	}
+	return nil, fmt.Errorf("no language profile for the '%s' alphabet", alpha.Name)
+}
@@ -0,0 +1,518 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Estimates which cipher mode produced a ciphertext. The index of
+ * coincidence of the columns of every candidate period tells whether
+ * the key repeats (monoalphabetic, Didimus 2, Fibonacci 10, Primus up
+ * to 12, Vigenère the keyword length) or not at all (Autokey and
+ * Xorshift). The relative shifts of the columns then tell apart the
+ * modes that share a period, since the series of Fibonacci & Primus
+ * are public. The result is a confidence per mode so that the right
+ * attack can be picked automatically:
+ *
+ *	identifier, _ := cryptanalysis.NewModeIdentifier(alpha)
+ *	guess, _ := identifier.Identify(ciphered)
+ *	fmt.Println(guess.Best().Mode, guess.Period)
+ *-----------------------------------------------------------------*/
+package cryptanalysis
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/lordofscripts/caesardisk"
+	"github.com/lordofscripts/caesardisk/engine"
+	"github.com/lordofscripts/caesardisk/internal/cipher"
+)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+const (
+	// the longest key period that is looked for
+	MaxIdentifiedPeriod = 24
+	// letters needed in every column to measure a period
+	minColumnLetters = 6
+	// how language-like & significant (in standard deviations of a
+	// random text) the columns of a period must be
+	minPeriodScore  = 0.6
+	minSignificance = 2.5
+	// fraction of the best period score a period must reach, the best
+	// limited since repetitions in the plaintext score above 1.
+	periodTolerance  = 0.85
+	maxPeriodCeiling = 1.2
+	// chi-squared per letter beyond which a decryption is not language
+	maxDivergence = 1.5
+)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// The confidence (0..1) that a cipher mode produced the ciphertext
+type ModeEstimate struct {
+	Mode       engine.Mode
+	Confidence float64
+}
+
+// The outcome of a cipher mode identification
+type Identification struct {
+	Period    int     // the key period, zero if the key never repeats
+	IoC       float64 // index of coincidence of the whole ciphertext
+	Estimates []ModeEstimate
+}
+
+// Estimates the cipher mode of the ciphertexts of an alphabet
+type ModeIdentifier struct {
+	alpha     *caesardisk.AlphabetModel
+	profile   *LanguageProfile
+	keying    engine.KeyingPolicy
+	fibonacci engine.FibonacciSeries
+	primes    engine.PrimeWindow
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// (ctor) a cipher mode identifier for a built-in alphabet, which is
+// compared with the letter frequencies of its language.
+func NewModeIdentifier(alpha *caesardisk.AlphabetModel) (*ModeIdentifier, error) {
+	profile, err := LanguageProfileFor(alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewModeIdentifierWithProfile(alpha, profile), nil
+}
+
+// (ctor) a cipher mode identifier for any alphabet compared with the
+// given language profile. It assumes the default series & primes.
+func NewModeIdentifierWithProfile(alpha *caesardisk.AlphabetModel, profile *LanguageProfile) *ModeIdentifier {
+	return &ModeIdentifier{
+		alpha:     alpha,
+		profile:   profile,
+		keying:    engine.SkipForeignKeying,
+		fibonacci: engine.DefaultFibonacciSeries,
+		primes:    engine.DefaultPrimeWindow,
+	}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// implements fmt.Stringer
+func (me ModeEstimate) String() string {
+	return fmt.Sprintf("%s %.0f%%", me.Mode, me.Confidence*100)
+}
+
+// implements fmt.Stringer
+func (id Identification) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Period:%d IoC:%.4f", id.Period, id.IoC)
+	for _, estimate := range id.Estimates {
+		if estimate.Confidence >= 0.01 {
+			sb.WriteString(", " + estimate.String())
+		}
+	}
+	return sb.String()
+}
+
+// the most likely cipher mode
+func (id Identification) Best() ModeEstimate {
+	if len(id.Estimates) == 0 {
+		return ModeEstimate{}
+	}
+	return id.Estimates[0]
+}
+
+// the confidence that the cipher mode produced the ciphertext
+func (id Identification) Confidence(mode engine.Mode) float64 {
+	for _, estimate := range id.Estimates {
+		if estimate.Mode == mode {
+			return estimate.Confidence
+		}
+	}
+	return 0
+}
+
+// the keying policy the message was encrypted with
+func (mi *ModeIdentifier) SetKeyingPolicy(policy engine.KeyingPolicy) *ModeIdentifier {
+	mi.keying = policy
+	return mi
+}
+
+// the Fibonacci series the message would be encrypted with
+func (mi *ModeIdentifier) SetFibonacciSeries(series engine.FibonacciSeries) *ModeIdentifier {
+	mi.fibonacci = series
+	return mi
+}
+
+// the window of primes the message would be encrypted with
+func (mi *ModeIdentifier) SetPrimeWindow(window engine.PrimeWindow) *ModeIdentifier {
+	mi.primes = window
+	return mi
+}
+
+// estimates the confidence of every built-in cipher mode, ranked from
+// the most likely. The ciphertext needs a dozen letters at least, and
+// longer periods are only measurable in longer ciphertexts.
+func (mi *ModeIdentifier) Identify(ciphered string) (Identification, error) {
+	indices := keyedIndices(ciphered, mi.alpha, mi.keying)
+	letters := 0
+	for _, at := range indices {
+		if at != -1 {
+			letters++
+		}
+	}
+	if letters < 2*minColumnLetters {
+		return Identification{}, fmt.Errorf("need at least %d letters to identify the cipher mode", 2*minColumnLetters)
+	}
+
+	// I. Autokey decrypts to the language with the right primer (the
+	//    ciphertext variant with any primer) though its key never
+	//    repeats and its plaintext variant may even look periodic.
+	autokeyCipher, err := mi.autokeyLikeness(engine.AutokeyCipherMode, ciphered)
+	if err != nil {
+		return Identification{}, err
+	}
+	autokey, err := mi.autokeyLikeness(engine.AutokeyMode, ciphered)
+	if err != nil {
+		return Identification{}, err
+	}
+	autokey = max(autokey, autokeyCipher)
+
+	// II. The key period: the columns of a periodic cipher are Caesar
+	//     ciphertexts with the index of coincidence of the language.
+	period, strength := mi.period(indices)
+	strength *= 1 - autokey
+	id := Identification{
+		Period: period,
+		IoC:    periodicIoC(indices, mi.alpha.Length(), 1),
+	}
+
+	weights := make(map[engine.Mode]float64)
+	if strength > 0 {
+		if period == 1 {
+			err = mi.weighMonoalphabetic(ciphered, strength, weights)
+		} else {
+			err = mi.weighPeriodic(indices, period, strength, weights)
+		}
+		if err != nil {
+			return Identification{}, err
+		}
+	} else {
+		id.Period = 0
+	}
+
+	// III. The key never repeats: Autokey or else Xorshift
+	rest := 1 - strength
+	weights[engine.AutokeyCipherMode] += rest * autokeyCipher
+	weights[engine.AutokeyMode] += rest * (autokey - autokeyCipher)
+	weights[engine.XorshiftMode] += rest * (1 - autokey)
+
+	id.Estimates = rankEstimates(weights)
+	return id, nil
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// the shortest period whose columns look like the language and how
+// strongly (0..1) they do so. Small columns have a noisy index of
+// coincidence, so the period must be significant against chance. The
+// columns of a divisor of the key period (Didimus at 1, Primus 12 at 6)
+// or of an unrelated one only partly look like the language, so the
+// period must also score about as high as the lower bound of the best.
+func (mi *ModeIdentifier) period(indices []int) (int, float64) {
+	longest := min(MaxIdentifiedPeriod, len(indices)/minColumnLetters)
+	scores := make([]float64, longest+1)
+	ceiling := 0.0
+	for p := 1; p <= longest; p++ {
+		var deviation float64
+		scores[p], deviation = mi.periodScore(indices, p)
+		ceiling = max(ceiling, scores[p]-2*deviation)
+		if scores[p] < minSignificance*deviation {
+			scores[p] = 0
+		}
+	}
+	ceiling = min(ceiling, maxPeriodCeiling)
+
+	for p := 1; p <= longest; p++ {
+		if scores[p] >= minPeriodScore && scores[p] >= periodTolerance*ceiling {
+			// a random text scores about 0, the language about 1
+			return p, clamp((scores[p] - 0.3) / 0.4)
+		}
+	}
+	return 0, 0
+}
+
+// where the coincidences of the columns of the period lie between those
+// of a random text (0) and those of the language (1), along with the
+// standard deviation of that score for a random text.
+func (mi *ModeIdentifier) periodScore(indices []int, period int) (float64, float64) {
+	N := mi.alpha.Length()
+	coincidences, pairs := 0, 0
+	for column := 0; column < period; column++ {
+		counts := make([]int, N)
+		total := 0
+		for i := column; i < len(indices); i += period {
+			if indices[i] != -1 {
+				counts[indices[i]]++
+				total++
+			}
+		}
+		for _, n := range counts {
+			coincidences += n * (n - 1) / 2
+		}
+		pairs += total * (total - 1) / 2
+	}
+	if pairs == 0 {
+		return 0, math.Inf(1)
+	}
+
+	random := 1 / float64(N)
+	span := mi.profile.IndexOfCoincidence(mi.alpha) - random
+	score := (float64(coincidences)/float64(pairs) - random) / span
+	deviation := math.Sqrt(float64(pairs)*random*(1-random)) / float64(pairs) / span
+	return score, deviation
+}
+
+// Caesar, Atbash & Affine substitute one letter for another. They are
+// told apart by the best fit to the language that each achieves over
+// all its keys. Affine excludes the multipliers of Caesar (1) & Atbash.
+func (mi *ModeIdentifier) weighMonoalphabetic(ciphered string, weight float64, weights map[engine.Mode]float64) error {
+	N := mi.alpha.Length()
+	fits := make(map[engine.Mode]float64)
+	for _, mode := range []engine.Mode{engine.CaesarMode, engine.AtbashMode, engine.AffineMode} {
+		fits[mode] = math.Inf(1)
+	}
+
+	try := func(mode engine.Mode, opts engine.Options) error {
+		caesar, err := engine.New(mode, mi.alpha, opts)
+		if err != nil {
+			return err
+		}
+		fits[mode] = min(fits[mode], mi.profile.ChiSquared(caesar.Decode(ciphered), mi.alpha))
+		return nil
+	}
+
+	for key := 0; key < N; key++ {
+		if err := try(engine.CaesarMode, engine.Options{Key: key}); err != nil {
+			return err
+		}
+		if err := try(engine.AtbashMode, engine.Options{Key: key}); err != nil {
+			return err
+		}
+		for multiplier := 2; multiplier < N-1; multiplier++ {
+			if _, warn := cipher.AffineCorrection(multiplier, mi.alpha); warn != nil {
+				continue // not coprime with N
+			}
+			if err := try(engine.AffineMode, engine.Options{Key: key, Multiplier: multiplier}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the lower the chi-squared the better, relative to the best fit
+	best := min(fits[engine.CaesarMode], fits[engine.AtbashMode], fits[engine.AffineMode])
+	total := 0.0
+	for mode, fit := range fits {
+		fits[mode] = math.Pow(best/fit, 4)
+		total += fits[mode]
+	}
+	for mode, fit := range fits {
+		weights[mode] += weight * fit / total
+	}
+	return nil
+}
+
+// Didimus, Fibonacci, Primus & Vigenère repeat their key schedule. The
+// shift of every column relative to the first matches the public series
+// of Fibonacci & Primus, any shifts are possible for a Vigenère keyword.
+func (mi *ModeIdentifier) weighPeriodic(indices []int, period int, weight float64, weights map[engine.Mode]float64) error {
+	shifts, err := mi.columnShifts(indices, period)
+	if err != nil {
+		return err
+	}
+
+	evidence := make(map[engine.Mode]float64)
+	if period == 2 {
+		evidence[engine.DidimusMode] = 1
+	}
+	if fibonacci, err := mi.schedule(engine.FibonacciMode, 0); err != nil {
+		return err
+	} else if len(fibonacci) == period {
+		evidence[engine.FibonacciMode] = matchShifts(shifts, fibonacci, mi.alpha.Length())
+	}
+	if primes := cipher.PrimusMaximus(mi.primes); period <= primes {
+		offset := period - 1
+		if period == primes {
+			offset = 0
+		}
+		primus, err := mi.schedule(engine.PrimusMode, offset)
+		if err != nil {
+			return err
+		}
+		evidence[engine.PrimusMode] = matchShifts(shifts, primus[:period], mi.alpha.Length())
+	}
+
+	strongest := 0.0
+	for _, e := range evidence {
+		strongest = max(strongest, e)
+	}
+	evidence[engine.VigenereMode] = 1 - strongest
+
+	total := 0.0
+	for _, e := range evidence {
+		total += e
+	}
+	for mode, e := range evidence {
+		weights[mode] += weight * e / total
+	}
+	return nil
+}
+
+// how much (0..1) the best decryption with a single Autokey primer
+// fits the language. The primer of the ciphertext variant only affects
+// the first letter.
+func (mi *ModeIdentifier) autokeyLikeness(mode engine.Mode, ciphered string) (float64, error) {
+	primers := mi.alpha.Length()
+	if mode == engine.AutokeyCipherMode {
+		primers = 1
+	}
+
+	best := math.Inf(1)
+	for key := 0; key < primers; key++ {
+		caesar, err := engine.New(mode, mi.alpha, engine.Options{Key: key, Keying: mi.keying})
+		if err != nil {
+			return 0, err
+		}
+		best = min(best, mi.divergence(caesar.Decode(ciphered)))
+	}
+	return clamp((maxDivergence - best) / maxDivergence * 2), nil
+}
+
+// the chi-squared statistic per letter of a text. It stays low for the
+// language however long the text, whereas it grows with the length for
+// anything else.
+func (mi *ModeIdentifier) divergence(text string) float64 {
+	letters := 0
+	for _, at := range keyedIndices(text, mi.alpha, engine.SkipForeignKeying) {
+		if at != -1 {
+			letters++
+		}
+	}
+	if letters == 0 {
+		return math.Inf(1)
+	}
+	return mi.profile.ChiSquared(text, mi.alpha) / float64(letters)
+}
+
+// the Caesar shift of every column relative to that of the first one
+func (mi *ModeIdentifier) columnShifts(indices []int, period int) ([]int, error) {
+	columns := make([]strings.Builder, period)
+	for i, at := range indices {
+		if at == -1 {
+			continue // a foreign character that only takes a key position
+		}
+		char, err := mi.alpha.Character(at)
+		if err != nil {
+			return nil, err
+		}
+		columns[i%period].WriteRune(char)
+	}
+
+	solver := NewCaesarSolverWithProfile(mi.alpha, mi.profile)
+	N := mi.alpha.Length()
+	shifts := make([]int, period)
+	for j := range columns {
+		best, err := solver.Best(columns[j].String())
+		if err != nil {
+			return nil, err
+		}
+		shifts[j] = best.Key
+	}
+	for j := period - 1; j >= 0; j-- {
+		shifts[j] = ((shifts[j]-shifts[0])%N + N) % N
+	}
+	return shifts, nil
+}
+
+// the key shifts of a series-based mode with a zero main key
+func (mi *ModeIdentifier) schedule(mode engine.Mode, offset int) ([]int, error) {
+	fibonacci, primes := mi.fibonacci, mi.primes
+	seq, err := engine.PrepareSequencer(mode, mi.alpha, engine.Options{
+		Offset:    offset,
+		Fibonacci: &fibonacci,
+		Primes:    &primes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	raw := seq.GetRawKeySchedule()
+	if len(raw) == 0 {
+		return nil, errors.New("empty key schedule for " + string(mode))
+	}
+	shifts := make([]int, len(raw))
+	for i, item := range raw {
+		shifts[i] = item.KeyShift
+	}
+	return shifts, nil
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+// the fraction of relative shifts (but the first, always zero) that
+// agree with those of the key schedule.
+func matchShifts(shifts, schedule []int, N int) float64 {
+	matches := 0
+	for j := 1; j < len(shifts); j++ {
+		if shifts[j] == ((schedule[j]-schedule[0])%N+N)%N {
+			matches++
+		}
+	}
+	return float64(matches) / float64(len(shifts)-1)
+}
+
+// the estimates of all the built-in cipher modes from the most likely,
+// with confidences that add up to one.
+func rankEstimates(weights map[engine.Mode]float64) []ModeEstimate {
+	const epsilon = 1e-3 // no mode is ever ruled out completely
+	modes := []engine.Mode{
+		engine.CaesarMode, engine.DidimusMode, engine.FibonacciMode,
+		engine.PrimusMode, engine.VigenereMode, engine.AutokeyMode,
+		engine.AutokeyCipherMode, engine.AffineMode, engine.AtbashMode,
+		engine.XorshiftMode,
+	}
+
+	total := 0.0
+	for _, mode := range modes {
+		total += weights[mode] + epsilon
+	}
+	estimates := make([]ModeEstimate, len(modes))
+	for i, mode := range modes {
+		estimates[i] = ModeEstimate{Mode: mode, Confidence: (weights[mode] + epsilon) / total}
+	}
+
+	sort.SliceStable(estimates, func(i, j int) bool {
+		return estimates[i].Confidence > estimates[j].Confidence
+	})
+	return estimates
+}
+
+// limits the value to 0..1
+func clamp(value float64) float64 {
+	return math.Max(0, math.Min(1, value))
+}
@@ -0,0 +1,191 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * An exhaustive attack on Fibonacci & Primus. Their key shifts are
+ * the main key plus the terms of a public series (Fibonacci numbers
+ * or primes), so the whole message depends on a single unknown main
+ * key and, for Primus, the number of primes selected by the Offset.
+ * Every combination is tried and ranked by its chi-squared statistic.
+ *-----------------------------------------------------------------*/
+package cryptanalysis
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/lordofscripts/caesardisk"
+	"github.com/lordofscripts/caesardisk/engine"
+	"github.com/lordofscripts/caesardisk/internal/cipher"
+)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// A candidate decryption of an attack on a cipher mode. The Offset
+// is zero for modes that take none.
+type ModeCandidate struct {
+	Mode   engine.Mode
+	Offset int
+	Candidate
+}
+
+// Breaks Fibonacci & Primus ciphertexts of an alphabet by exhaustive
+// search of the main key (and Primus offset).
+type PeriodicSolver struct {
+	alpha     *caesardisk.AlphabetModel
+	profile   *LanguageProfile
+	keying    engine.KeyingPolicy
+	fibonacci engine.FibonacciSeries
+	primes    engine.PrimeWindow
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// (ctor) a Fibonacci & Primus solver for a built-in alphabet, which is
+// scored with the letter frequencies of its language.
+func NewPeriodicSolver(alpha *caesardisk.AlphabetModel) (*PeriodicSolver, error) {
+	profile, err := LanguageProfileFor(alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPeriodicSolverWithProfile(alpha, profile), nil
+}
+
+// (ctor) a Fibonacci & Primus solver for any alphabet scored with the
+// given language profile. It assumes the default series & primes.
+func NewPeriodicSolverWithProfile(alpha *caesardisk.AlphabetModel, profile *LanguageProfile) *PeriodicSolver {
+	return &PeriodicSolver{
+		alpha:     alpha,
+		profile:   profile,
+		keying:    engine.SkipForeignKeying,
+		fibonacci: engine.DefaultFibonacciSeries,
+		primes:    engine.DefaultPrimeWindow,
+	}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// implements fmt.Stringer
+func (mc ModeCandidate) String() string {
+	return fmt.Sprintf("%s(%c|%02d,%d) χ²=%.2f %s", mc.Mode, mc.KeyChar, mc.Key, mc.Offset, mc.ChiSquared, mc.Plain)
+}
+
+// the keying policy the message was encrypted with
+func (ps *PeriodicSolver) SetKeyingPolicy(policy engine.KeyingPolicy) *PeriodicSolver {
+	ps.keying = policy
+	return ps
+}
+
+// the Fibonacci series the message was encrypted with
+func (ps *PeriodicSolver) SetFibonacciSeries(series engine.FibonacciSeries) *PeriodicSolver {
+	ps.fibonacci = series
+	return ps
+}
+
+// the window of primes the message was encrypted with
+func (ps *PeriodicSolver) SetPrimeWindow(window engine.PrimeWindow) *PeriodicSolver {
+	ps.primes = window
+	return ps
+}
+
+// tries every main key of Fibonacci and returns the candidates ranked
+// from the most to the least plausible plaintext.
+func (ps *PeriodicSolver) SolveFibonacci(ciphered string) ([]ModeCandidate, error) {
+	candidates := make([]ModeCandidate, 0, ps.alpha.Length())
+	for key := 0; key < ps.alpha.Length(); key++ {
+		candidate, err := ps.try(engine.FibonacciMode, key, 0, ciphered)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return rankModeCandidates(candidates), nil
+}
+
+// tries every main key & number of primes of Primus and returns the
+// candidates ranked from the most to the least plausible plaintext.
+// Offsets that repeat the key schedule of another are skipped, i.e.
+// the last one which, like zero, selects all the primes.
+func (ps *PeriodicSolver) SolvePrimus(ciphered string) ([]ModeCandidate, error) {
+	offsets := cipher.PrimusMaximus(ps.primes) - 1
+	candidates := make([]ModeCandidate, 0, ps.alpha.Length()*offsets)
+	for key := 0; key < ps.alpha.Length(); key++ {
+		for offset := 0; offset < offsets; offset++ {
+			candidate, err := ps.try(engine.PrimusMode, key, offset, ciphered)
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return rankModeCandidates(candidates), nil
+}
+
+// the candidates of both Fibonacci & Primus in a single ranking, for
+// when the cipher mode is unknown too.
+func (ps *PeriodicSolver) Solve(ciphered string) ([]ModeCandidate, error) {
+	fibonacci, err := ps.SolveFibonacci(ciphered)
+	if err != nil {
+		return nil, err
+	}
+	primus, err := ps.SolvePrimus(ciphered)
+	if err != nil {
+		return nil, err
+	}
+
+	return rankModeCandidates(append(fibonacci, primus...)), nil
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// decrypts & scores the ciphertext with one combination of unknowns
+func (ps *PeriodicSolver) try(mode engine.Mode, key, offset int, ciphered string) (ModeCandidate, error) {
+	fibonacci, primes := ps.fibonacci, ps.primes
+	caesar, err := engine.New(mode, ps.alpha, engine.Options{
+		Key:       key,
+		Offset:    offset,
+		Keying:    ps.keying,
+		Fibonacci: &fibonacci,
+		Primes:    &primes,
+	})
+	if err != nil {
+		return ModeCandidate{}, err
+	}
+
+	plain := caesar.Decode(ciphered)
+	keyChar, _ := ps.alpha.Character(key)
+	return ModeCandidate{
+		Mode:   mode,
+		Offset: offset,
+		Candidate: Candidate{
+			Key:        key,
+			KeyChar:    keyChar,
+			Plain:      plain,
+			ChiSquared: ps.profile.ChiSquared(plain, ps.alpha),
+		},
+	}, nil
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+// sorts the candidates from the lowest (best) chi-squared statistic
+func rankModeCandidates(candidates []ModeCandidate) []ModeCandidate {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].ChiSquared < candidates[j].ChiSquared
+	})
+	return candidates
+}
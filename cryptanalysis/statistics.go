@@ -0,0 +1,225 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Statistics of ciphertexts over an alphabet. Like the sequencers
+ * they only look at the characters of the alphabet, the index of
+ * coincidence is the probability that two of them drawn at random
+ * are the same letter: high for a natural language (and thus for a
+ * monoalphabetic cipher) and about 1/N for a random text.
+ *
+ * The key period of a polyalphabetic cipher is estimated with the
+ * Friedman test (from the index of coincidence) and the Kasiski
+ * examination (from the distances between repeated trigrams, which
+ * are usually multiples of the period).
+ *-----------------------------------------------------------------*/
+package cryptanalysis
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lordofscripts/caesardisk"
+	"github.com/lordofscripts/caesardisk/engine"
+)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+// the length of the sequences of the Kasiski examination
+const KasiskiLength = 3
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// The occurrences of a letter of the alphabet in a text
+type LetterCount struct {
+	Letter    rune
+	Count     int
+	Frequency float64 // fraction of the letters of the text
+}
+
+// A sequence of letters that occurs more than once in a text
+type Repetition struct {
+	Sequence  string
+	Positions []int // the key positions where it starts
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// the distances between the successive occurrences of the sequence
+func (r Repetition) Distances() []int {
+	distances := make([]int, 0, len(r.Positions))
+	for i := 1; i < len(r.Positions); i++ {
+		distances = append(distances, r.Positions[i]-r.Positions[i-1])
+	}
+	return distances
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+// The count of every letter of the alphabet (in alphabet order) in the
+// text regardless of case. Foreign characters are not counted.
+func LetterFrequencyTable(text string, alpha *caesardisk.AlphabetModel) []LetterCount {
+	table := make([]LetterCount, alpha.Length())
+	for i, letter := range []rune(alpha.String()) {
+		table[i].Letter = letter
+	}
+
+	letters := 0
+	for _, at := range keyedIndices(text, alpha, engine.SkipForeignKeying) {
+		table[at].Count++
+		letters++
+	}
+	if letters > 0 {
+		for i := range table {
+			table[i].Frequency = float64(table[i].Count) / float64(letters)
+		}
+	}
+
+	return table
+}
+
+// The index of coincidence of the letters of the text that are in the
+// alphabet (regardless of case). Zero for less than two letters.
+func IndexOfCoincidence(text string, alpha *caesardisk.AlphabetModel) float64 {
+	return periodicIoC(keyedIndices(text, alpha, engine.SkipForeignKeying), alpha.Length(), 1)
+}
+
+// The mean index of coincidence of the letters that would share a key
+// of the given period, i.e. every period-th key position as per the
+// keying policy. It approaches that of the language at the key period
+// (or a multiple of it) of a polyalphabetic cipher.
+func PeriodicIndexOfCoincidence(text string, alpha *caesardisk.AlphabetModel, keying engine.KeyingPolicy, period int) float64 {
+	if period < 1 {
+		return 0
+	}
+	return periodicIoC(keyedIndices(text, alpha, keying), alpha.Length(), period)
+}
+
+// The Friedman estimate of the key period of a polyalphabetic cipher
+// whose plaintext is in the language of the profile. It is a rough
+// estimate of the number of alphabets (about 1 for a monoalphabetic
+// cipher) and zero for less than two letters.
+func FriedmanPeriod(text string, alpha *caesardisk.AlphabetModel, profile *LanguageProfile) float64 {
+	letters := float64(len(keyedIndices(text, alpha, engine.SkipForeignKeying)))
+	ioc := IndexOfCoincidence(text, alpha)
+	if letters < 2 {
+		return 0
+	}
+
+	random := 1 / float64(alpha.Length())
+	language := profile.IndexOfCoincidence(alpha)
+	denominator := (letters-1)*ioc - letters*random + language
+	if denominator <= 0 {
+		return letters // as random as it gets
+	}
+	return (language - random) * letters / denominator
+}
+
+// The Kasiski examination: every trigram of the text that repeats at
+// consecutive key positions (as per the keying policy) along with the
+// positions of its occurrences. The most repeated come first.
+func KasiskiRepetitions(text string, alpha *caesardisk.AlphabetModel, keying engine.KeyingPolicy) []Repetition {
+	indices := keyedIndices(text, alpha, keying)
+	runic := []rune(alpha.String())
+
+	positions := make(map[string][]int)
+	order := make([]string, 0)
+	for start := 0; start+KasiskiLength <= len(indices); start++ {
+		var sb strings.Builder
+		for _, at := range indices[start : start+KasiskiLength] {
+			if at == -1 {
+				break // a foreign character breaks the sequence
+			}
+			sb.WriteRune(runic[at])
+		}
+		if sequence := sb.String(); len([]rune(sequence)) == KasiskiLength {
+			if _, seen := positions[sequence]; !seen {
+				order = append(order, sequence)
+			}
+			positions[sequence] = append(positions[sequence], start)
+		}
+	}
+
+	repetitions := make([]Repetition, 0)
+	for _, sequence := range order {
+		if len(positions[sequence]) > 1 {
+			repetitions = append(repetitions, Repetition{Sequence: sequence, Positions: positions[sequence]})
+		}
+	}
+	sort.SliceStable(repetitions, func(i, j int) bool {
+		return len(repetitions[i].Positions) > len(repetitions[j].Positions)
+	})
+
+	return repetitions
+}
+
+// How many distances between the repetitions every period up to the
+// longest divides (the index is the period). The key period and its
+// divisors collect the most, though small periods collect by chance.
+func KasiskiFactors(repetitions []Repetition, longest int) []int {
+	factors := make([]int, longest+1)
+	for _, repetition := range repetitions {
+		for _, distance := range repetition.Distances() {
+			for period := 2; period <= longest; period++ {
+				if distance%period == 0 {
+					factors[period]++
+				}
+			}
+		}
+	}
+	return factors
+}
+
+// the alphabet index of every character that takes a key position
+// under the keying policy, -1 for a foreign one (count-all keying).
+func keyedIndices(text string, alpha *caesardisk.AlphabetModel, keying engine.KeyingPolicy) []int {
+	indices := make([]int, 0, len(text))
+	for _, char := range alpha.Normalize(text) {
+		at := alpha.Find(char)
+		if at != -1 || keying.KeysForeign() {
+			indices = append(indices, at)
+		}
+	}
+	return indices
+}
+
+// the mean index of coincidence of the columns of the key positions
+// for the period, i.e. of the letters encrypted with the same key if
+// the cipher has that period.
+func periodicIoC(indices []int, N int, period int) float64 {
+	sum, columns := 0.0, 0
+	for column := 0; column < period; column++ {
+		counts := make([]int, N)
+		total := 0
+		for i := column; i < len(indices); i += period {
+			if indices[i] != -1 {
+				counts[indices[i]]++
+				total++
+			}
+		}
+		if total < 2 {
+			continue
+		}
+
+		pairs := 0
+		for _, n := range counts {
+			pairs += n * (n - 1)
+		}
+		sum += float64(pairs) / float64(total*(total-1))
+		columns++
+	}
+	if columns == 0 {
+		return 0
+	}
+
+	return sum / float64(columns)
+}
@@ -7,46 +7,101 @@
  *-----------------------------------------------------------------*/
 package crypto
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/lordofscripts/caesardisk/internal/cipher"
+)
 
 /* ----------------------------------------------------------------
  *						G l o b a l s
  *-----------------------------------------------------------------*/
 
+// the built-in modes, in the order of registration (see modeRegistry)
 const (
 	CaesarMode CaesarCipherMode = iota
 	DidimusMode
 	FibonacciMode
 	PrimusMode
+	VigenereMode
+	AutokeyMode       // plaintext feedback
+	AutokeyCipherMode // ciphertext feedback
+	AffineMode
+	AtbashMode
+	XorshiftMode // seeded PRNG key stream
 )
 
-var (
-	cipherModeToString map[CaesarCipherMode]string = map[CaesarCipherMode]string{
-		CaesarMode:    "Caesar",
-		DidimusMode:   "Didimus",
-		FibonacciMode: "Fibonacci",
-		PrimusMode:    "Primus",
-	}
-	cipherModeFromString map[string]CaesarCipherMode = map[string]CaesarCipherMode{
-		"Caesar":    CaesarMode,
-		"Didimus":   DidimusMode,
-		"Fibonacci": FibonacciMode,
-		"Primus":    PrimusMode,
-	}
+const (
+	// C = P + K the usual "shift forward" Caesar
+	StandardDirection CipherDirection = cipher.StandardDirection
+	// C = K - P which is reciprocal (encryption same as decryption)
+	BeaufortDirection CipherDirection = cipher.BeaufortDirection
+	// C = P - K
+	VariantBeaufortDirection CipherDirection = cipher.VariantBeaufortDirection
 )
 
+const (
+	// only alphabet characters advance the key (default)
+	SkipForeignKeying KeyingPolicy = cipher.SkipForeignKeying
+	// every character, including spaces & punctuation, advances the key
+	CountAllKeying KeyingPolicy = cipher.CountAllKeying
+)
+
+// the classic 10-term Fibonacci series {0,1,1,2,3,5,8,13,21,34}
+var DefaultFibonacciSeries FibonacciSeries = cipher.DefaultFibonacciSeries
+
+// the first 11 primes {2,3,5,...,31}
+var DefaultPrimeWindow PrimeWindow = cipher.DefaultPrimeWindow
+
 /* ----------------------------------------------------------------
  *				P u b l i c		T y p e s
  *-----------------------------------------------------------------*/
 
 type CaesarCipherMode uint8
 
+// The operation combining plain character and key shift. It applies
+// to every CaesarCipherMode.
+type CipherDirection = cipher.Direction
+
+// Which characters of the message advance the key sequence. It
+// applies to every CaesarCipherMode.
+type KeyingPolicy = cipher.KeyingPolicy
+
+// The length and seeds of the (generalized) Fibonacci series used
+// by FibonacciMode. See DefaultFibonacciSeries.
+type FibonacciSeries = cipher.FibonacciSeries
+
+// The window of consecutive primes used by PrimusMode. See
+// DefaultPrimeWindow.
+type PrimeWindow = cipher.PrimeWindow
+
 /* ----------------------------------------------------------------
  *				P u b l i c		M e t h o d s
  *-----------------------------------------------------------------*/
 
 func (cm CaesarCipherMode) String() string {
-	return cipherModeToString[cm]
+	if spec, ok := modeRegistry.lookup(cm); ok {
+		return spec.Name
+	}
+	return fmt.Sprintf("CaesarCipherMode(%d)", cm)
+}
+
+// whether the parameter is one of the extra arguments of the mode
+func (cm CaesarCipherMode) Uses(param ModeParam) bool {
+	spec, ok := modeRegistry.lookup(cm)
+	return ok && spec.Uses(param)
+}
+
+// whether the Offset is one of the extra arguments of the mode
+func (cm CaesarCipherMode) UsesOffset() bool {
+	spec, ok := modeRegistry.lookup(cm)
+	return ok && spec.UsesOffset()
+}
+
+// whether the inner ring of the disk turns counter-clockwise (Atbash)
+func (cm CaesarCipherMode) IsReversed() bool {
+	spec, ok := modeRegistry.lookup(cm)
+	return ok && spec.Reversed
 }
 
 /* ----------------------------------------------------------------
@@ -54,7 +109,7 @@ func (cm CaesarCipherMode) String() string {
  *-----------------------------------------------------------------*/
 
 func ParseCipherMode(s string) (CaesarCipherMode, error) {
-	if val, ok := cipherModeFromString[s]; ok {
+	if val, ok := modeRegistry.parse(s); ok {
 		return val, nil
 	} else {
 		return CaesarMode, fmt.Errorf("invalid CipherMode name: %s", s)
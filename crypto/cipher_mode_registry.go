@@ -0,0 +1,272 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * The registry of the cipher modes known to the CipherController. Each
+ * mode registers once its name, the extra arguments it takes and its
+ * sequencer factory (see the engine package). The controller, the CLI
+ * and the GUI discover the modes & their requirements from here. The
+ * key schedule of every mode is built from its own key sequencer.
+ *-----------------------------------------------------------------*/
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/lordofscripts/caesardisk/engine"
+)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+const (
+	// int: Didimus offset, Primus number of primes, Xorshift seed
+	OffsetParam ModeParam = iota
+	// int: Affine multiplier
+	MultiplierParam
+	// string: Vigenère keyword, Autokey primer
+	KeywordParam
+	// string: Xorshift passphrase
+	PassphraseParam
+	// FibonacciSeries
+	SeriesParam
+)
+
+var modeParamToString map[ModeParam]string = map[ModeParam]string{
+	OffsetParam:     "Offset",
+	MultiplierParam: "Multiplier",
+	KeywordParam:    "Keyword",
+	PassphraseParam: "Passphrase",
+	SeriesParam:     "Series",
+}
+
+// the built-in modes, registered in CaesarCipherMode order
+var modeRegistry = newCipherModeRegistry([]CipherModeSpec{
+	{Name: "Caesar"},
+	{Name: "Didimus", Params: []ModeParam{OffsetParam}, Required: 1},
+	{Name: "Fibonacci", Params: []ModeParam{SeriesParam}},
+	{Name: "Primus", Params: []ModeParam{OffsetParam}, OffsetRange: primusOffsetRange},
+	{Name: "Vigenère", Params: []ModeParam{KeywordParam}, Required: 1},
+	{Name: "Autokey", Params: []ModeParam{KeywordParam}},
+	{Name: "Autokey (ciphertext)", Params: []ModeParam{KeywordParam}},
+	{Name: "Affine", Params: []ModeParam{MultiplierParam}, Required: 1},
+	{Name: "Atbash", Reversed: true},
+	{Name: "Xorshift", Params: []ModeParam{OffsetParam, PassphraseParam}},
+})
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// The kind of an extra argument of Encrypt() & Decrypt()
+type ModeParam uint8
+
+// The registration of a cipher mode with the controller.
+type CipherModeSpec struct {
+	// unique name, also that of the engine.Mode
+	Name string
+	// the extra arguments of Encrypt() & Decrypt() in order, of which
+	// the first Required are mandatory.
+	Params   []ModeParam
+	Required int
+	// the valid Offset values, nil for any key shift of the alphabet
+	OffsetRange func(cc *CipherController) (min, max int)
+	// the inner ring turns counter-clockwise (Atbash)
+	Reversed bool
+	// creates the key sequencer. Leave nil for a mode that is already
+	// registered with the engine package.
+	Factory engine.SequencerFactory
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	T y p e s
+ *-----------------------------------------------------------------*/
+
+type cipherModeRegistry struct {
+	sync.RWMutex
+	specs  []CipherModeSpec // indexed by CaesarCipherMode
+	byName map[string]CaesarCipherMode
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// (ctor) the registry with the built-in cipher modes
+func newCipherModeRegistry(builtin []CipherModeSpec) *cipherModeRegistry {
+	registry := &cipherModeRegistry{
+		byName: make(map[string]CaesarCipherMode),
+	}
+	for _, spec := range builtin {
+		if _, err := registry.register(spec); err != nil {
+			panic(err)
+		}
+	}
+
+	return registry
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// implements fmt.Stringer
+func (mp ModeParam) String() string {
+	if name, ok := modeParamToString[mp]; ok {
+		return name
+	}
+	return fmt.Sprintf("ModeParam(%d)", mp)
+}
+
+// whether the parameter is one of the extra arguments of the mode
+func (s CipherModeSpec) Uses(param ModeParam) bool {
+	return slices.Contains(s.Params, param)
+}
+
+// whether the Offset is one of the extra arguments of the mode
+func (s CipherModeSpec) UsesOffset() bool {
+	return s.Uses(OffsetParam)
+}
+
+// whether the mode works with just the main key & (if used) the
+// Offset, i.e. it needs no other mandatory argument.
+func (s CipherModeSpec) IsSimple() bool {
+	for _, param := range s.Params[:s.Required] {
+		if param != OffsetParam {
+			return false
+		}
+	}
+	return true
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// the typed engine options of the mode from the variadic arguments
+// of Encrypt(). The role (encryptor/decryptor) is only used in error
+// messages. A missing Offset is zero like in engine.Options, so both
+// APIs encrypt alike.
+func (s CipherModeSpec) options(role string, keyShift int, args ...any) (engine.Options, error) {
+	opts := engine.Options{
+		Key: keyShift,
+	}
+	for i, param := range s.Params {
+		if i >= len(args) {
+			if i < s.Required {
+				return opts, fmt.Errorf("missing %s parameter to %s %s", param, s.Name, role)
+			}
+			break
+		}
+
+		var ok bool
+		switch param {
+		case OffsetParam:
+			opts.Offset, ok = args[i].(int)
+		case MultiplierParam:
+			opts.Multiplier, ok = args[i].(int)
+		case KeywordParam, PassphraseParam:
+			opts.Keyword, ok = args[i].(string)
+		case SeriesParam:
+			var series FibonacciSeries
+			if series, ok = args[i].(FibonacciSeries); ok {
+				opts.Fibonacci = &series
+			}
+		}
+		if !ok {
+			return opts, fmt.Errorf("invalid %s parameter type to %s", param, s.Name)
+		}
+	}
+
+	return opts, nil
+}
+
+func (r *cipherModeRegistry) register(spec CipherModeSpec) (CaesarCipherMode, error) {
+	if len(spec.Name) == 0 {
+		return 0, errors.New("cannot register a cipher mode without name")
+	}
+	if spec.Required < 0 || spec.Required > len(spec.Params) {
+		return 0, fmt.Errorf("cipher mode '%s' requires more parameters than it takes", spec.Name)
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	if _, exists := r.byName[spec.Name]; exists {
+		return 0, fmt.Errorf("cipher mode '%s' is already registered", spec.Name)
+	}
+	if len(r.specs) > int(^CaesarCipherMode(0)) {
+		return 0, fmt.Errorf("too many cipher modes to register '%s'", spec.Name)
+	}
+	if spec.Factory != nil {
+		if err := engine.Register(engine.Mode(spec.Name), spec.Factory); err != nil {
+			return 0, err
+		}
+	} else if !engine.IsRegistered(engine.Mode(spec.Name)) {
+		return 0, fmt.Errorf("cipher mode '%s' has no sequencer factory", spec.Name)
+	}
+
+	mode := CaesarCipherMode(len(r.specs))
+	r.specs = append(r.specs, spec)
+	r.byName[spec.Name] = mode
+
+	return mode, nil
+}
+
+func (r *cipherModeRegistry) lookup(mode CaesarCipherMode) (CipherModeSpec, bool) {
+	r.RLock()
+	defer r.RUnlock()
+
+	if int(mode) < len(r.specs) {
+		return r.specs[mode], true
+	}
+	return CipherModeSpec{}, false
+}
+
+func (r *cipherModeRegistry) parse(name string) (CaesarCipherMode, bool) {
+	r.RLock()
+	defer r.RUnlock()
+
+	mode, ok := r.byName[name]
+	return mode, ok
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+// Registers a custom cipher mode with the controller and returns its
+// CaesarCipherMode. The name must not be that of a registered mode.
+func RegisterCipherMode(spec CipherModeSpec) (CaesarCipherMode, error) {
+	return modeRegistry.register(spec)
+}
+
+// the registered cipher modes, the built-in ones first
+func CipherModes() []CaesarCipherMode {
+	modeRegistry.RLock()
+	defer modeRegistry.RUnlock()
+
+	modes := make([]CaesarCipherMode, len(modeRegistry.specs))
+	for i := range modes {
+		modes[i] = CaesarCipherMode(i)
+	}
+	return modes
+}
+
+// the registration of a cipher mode
+func LookupCipherMode(mode CaesarCipherMode) (CipherModeSpec, error) {
+	if spec, ok := modeRegistry.lookup(mode); ok {
+		return spec, nil
+	}
+	return CipherModeSpec{}, fmt.Errorf("unregistered cipher mode: %d", mode)
+}
+
+// the Primus Offset is the number of prime terms of the window
+func primusOffsetRange(cc *CipherController) (min, max int) {
+	return 0, cc.PrimusMaximus() - 1
+}
@@ -4,14 +4,19 @@
  *							   APP_NAME
  * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
  * A public controller for Caesar-class substitution cipher encryption
- * and decryption. It supports plain Caesar, Didimus, Fibonacci & Primus.
+ * and decryption. It supports plain Caesar, Didimus, Fibonacci, Primus,
+ * Vigenère, Autokey, Affine, Atbash & Xorshift.
  *-----------------------------------------------------------------*/
 package crypto
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
 
 	"github.com/lordofscripts/caesardisk"
+	"github.com/lordofscripts/caesardisk/engine"
 	"github.com/lordofscripts/caesardisk/internal/cipher"
 	"github.com/lordofscripts/caesardisk/internal/hash"
 	"github.com/lordofscripts/goapp/app/logx"
@@ -33,10 +38,22 @@ var hashSeed uint64 = 0xDEADBEA7
 
 // The CipherController object holds a reference alphabet and can
 // perform repeated, independent Encryption/Decryption operations
-// on that alphabet with different parameter values.
+// on that alphabet with different parameter values. It is safe for
+// concurrent use, its settings & operations are serialized.
 type CipherController struct {
 	ControllerBase
-	alpha *caesardisk.AlphabetModel
+	alpha     *caesardisk.AlphabetModel
+	direction CipherDirection
+	keying    KeyingPolicy
+	// optional plain text preprocessing & ciphertext grouping
+	normalization NormalizerOptions
+	// keyword for the mixed alphabet of the cipher ring (optional)
+	mixKeyword string
+	mixed      *caesardisk.AlphabetModel
+	// paired symbols of a dual disk (optional)
+	symbols *caesardisk.AlphabetModel
+	// the window of primes used by Primus
+	primeWindow PrimeWindow
 }
 
 /* ----------------------------------------------------------------
@@ -61,7 +78,10 @@ func NewCipherController(alpha *caesardisk.AlphabetModel, vwn IViewNotifier) *Ci
 		ControllerBase: ControllerBase{
 			viewNotify: vwn,
 		},
-		alpha: alpha,
+		alpha:       alpha,
+		direction:   StandardDirection,
+		keying:      SkipForeignKeying,
+		primeWindow: DefaultPrimeWindow,
 	}
 }
 
@@ -70,122 +90,287 @@ func NewCipherController(alpha *caesardisk.AlphabetModel, vwn IViewNotifier) *Ci
  *-----------------------------------------------------------------*/
 
 func (cc *CipherController) CloneWith(newAlpha *caesardisk.AlphabetModel) *CipherController {
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
+
 	alter := &CipherController{
 		ControllerBase: ControllerBase{
 			viewNotify: cc.viewNotify,
 		},
-		alpha: cc.alpha,
+		alpha:         cc.alpha,
+		direction:     cc.direction,
+		keying:        cc.keying,
+		normalization: cc.normalization,
+		symbols:       cc.symbols,
+		primeWindow:   cc.primeWindow,
 	}
 
 	if newAlpha != nil {
 		alter.alpha = newAlpha
 	}
+	// the mixed alphabet must be rebuilt for the new alphabet
+	alter.SetMixedAlphabet(cc.mixKeyword)
 	return alter
 }
 
-// Encrypt a plain string using the selected Caesar-class cipher mode
-// with the selected encryption parameters.
-func (cc *CipherController) Encrypt(mode CaesarCipherMode, plain string, keyShift int, args ...any) (string, error) {
-	var sequencer cipher.IKeySequencer
-	var p *cipher.CaesarParameters = cipher.NewCaesarParameters(cc.alpha)
-	logx.Enter()
-	defer logx.Leave()
+// set the direction (Standard, Beaufort, Variant Beaufort) used by all
+// subsequent operations and key schedules, regardless of cipher mode.
+func (cc *CipherController) SetDirection(dir CipherDirection) *CipherController {
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
+
+	cc.direction = dir
+	return cc
+}
 
+// the direction used by the cipher operations of this controller
+func (cc *CipherController) Direction() CipherDirection {
 	cc.mutexRW.Lock()
 	defer cc.mutexRW.Unlock()
 
-	// I. Select parameters and create sequencer
-	switch mode {
-	case CaesarMode:
-		p.SetKey(keyShift)
-		sequencer = cipher.NewCaesarSequencer(p)
+	return cc.direction
+}
 
-	case DidimusMode:
-		if len(args) != 1 {
-			return "", errors.New("missing Offset parameter to Didimus encryptor")
-		}
-		if ofs, ok := (args[0]).(int); ok {
-			p.SetKey(keyShift)
-			p.SetAltKeyOffset(ofs)
-			sequencer = cipher.NewDidimusSequencer(p)
-		} else {
-			return "", errors.New("invalid parameter type to Didimus")
-		}
+// set the keying policy, i.e. whether only alphabet characters or
+// all characters advance the key in subsequent operations.
+func (cc *CipherController) SetKeyingPolicy(policy KeyingPolicy) *CipherController {
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
 
-	case FibonacciMode:
-		p.SetKey(keyShift)
-		sequencer = cipher.NewFibonacciSequencer(p)
+	cc.keying = policy
+	return cc
+}
+
+// the keying policy used by the cipher operations of this controller
+func (cc *CipherController) KeyingPolicy() KeyingPolicy {
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
 
-	case PrimusMode:
-		p.SetKey(keyShift)
-		sequencer = cipher.NewPrimusSequencer(p)
+	return cc.keying
+}
+
+// set the paired alphabet of symbols (digits & punctuation) of a dual
+// disk, see caesardisk.DualSymbolsFor(). Its symbols are encrypted at
+// the same key schedule position as the letters would be. It must be
+// as long as the alphabet of letters. Use nil for a single alphabet.
+func (cc *CipherController) SetSymbolAlphabet(symbols *caesardisk.AlphabetModel) *CipherController {
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
+
+	cc.symbols = symbols
+	return cc
+}
 
-	default:
-		return "", errors.New("invalid cipher mode given to controller")
+// the paired alphabet of symbols of the dual disk (if any)
+func (cc *CipherController) SymbolAlphabet() *caesardisk.AlphabetModel {
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
+
+	return cc.symbols
+}
+
+// set the preprocessing of the plain text prior to Encrypt() and the
+// grouping of its ciphertext, see ClassicNormalization. Decrypt()
+// removes the grouping before decrypting, but normalization is of
+// course not reversible.
+func (cc *CipherController) SetNormalization(opts NormalizerOptions) *CipherController {
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
+
+	cc.normalization = opts
+	return cc
+}
+
+// the plain text normalization options of this controller
+func (cc *CipherController) Normalization() NormalizerOptions {
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
+
+	return cc.normalization
+}
+
+// set the window of primes (start index & number of terms) used by
+// all subsequent Primus operations and key schedules.
+func (cc *CipherController) SetPrimeWindow(window PrimeWindow) *CipherController {
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
+
+	cc.primeWindow = window
+	return cc
+}
+
+// the window of primes used by Primus in this controller
+func (cc *CipherController) PrimeWindow() PrimeWindow {
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
+
+	return cc.primeWindow
+}
+
+// the maximum Primus Offset (number of terms) for the prime window
+// of this controller.
+func (cc *CipherController) PrimusMaximus() int {
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
+
+	return cipher.PrimusMaximus(cc.primeWindow)
+}
+
+// use a keyword-mixed alphabet on the cipher (inner) ring for all
+// subsequent operations and key schedules. An empty keyword (or one
+// without letters of the alphabet) restores the plain cipher ring.
+func (cc *CipherController) SetMixedAlphabet(keyword string) *CipherController {
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
+
+	cc.mixKeyword = keyword
+	cc.mixed = nil
+	if len(keyword) != 0 {
+		cc.mixed = caesardisk.NewKeyedAlphabet(cc.alpha, keyword)
 	}
+	return cc
+}
 
-	// II. Validate parameters via sequencer
-	if err := sequencer.Validate(); err != nil {
+// the alphabet of the cipher (inner) ring, either the keyword-mixed
+// alphabet or the plain alphabet.
+func (cc *CipherController) CipherAlphabet() *caesardisk.AlphabetModel {
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
+
+	return cc.cipherAlphabet()
+}
+
+// Encrypt a plain string using the selected Caesar-class cipher mode
+// with the selected encryption parameters. Didimus takes the Offset (int),
+// Affine the Multiplier (int) and Vigenère the Keyword (string) as the
+// only extra argument. Autokey takes an optional primer (string), else
+// the main key is the primer. Fibonacci takes an optional FibonacciSeries
+// and Primus an optional Offset (int), the number of prime terms to use
+// (zero or none for all). Xorshift takes an optional Offset (int) and
+// Passphrase (string) seed. A missing Offset is zero.
+func (cc *CipherController) Encrypt(mode CaesarCipherMode, plain string, keyShift int, args ...any) (string, error) {
+	logx.Enter()
+	defer logx.Leave()
+
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
+
+	// I. Select parameters, create & validate sequencer
+	sequencer, err := cc.newSequencer("encryptor", mode, keyShift, args...)
+	if err != nil {
 		return "", err
 	}
 
-	// III. Encrypt
+	// II. Encrypt the (normalized) plain text
 	caesarHandler := cipher.NewCaesarCipherFromSequencer(sequencer)
+	if !cc.normalization.IsEnabled() {
+		return caesarHandler.Encode(plain), nil
+	}
 
-	return caesarHandler.Encode(plain), nil
+	normalizer := NewTextNormalizer(cc.alpha, cc.normalization).PairWith(cc.symbols)
+	if !normalizer.CanGroup() {
+		return "", errors.New("cannot group a ciphertext that may contain encrypted spaces")
+	}
+	return normalizer.Group(caesarHandler.Encode(normalizer.Normalize(plain))), nil
 }
 
 // Decrypts a Caesar-class string using the selected cipher mode and decryption
-// parameters.
+// parameters. The extra arguments are the same as for Encrypt().
 func (cc *CipherController) Decrypt(mode CaesarCipherMode, ciphered string, keyShift int, args ...any) (string, error) {
-	var sequencer cipher.IKeySequencer
-	var p *cipher.CaesarParameters = cipher.NewCaesarParameters(cc.alpha)
-
 	logx.Enter()
 	defer logx.Leave()
 
 	cc.mutexRW.Lock()
 	defer cc.mutexRW.Unlock()
 
-	// I. Select parameters and create sequencer
-	switch mode {
-	case CaesarMode:
-		p.SetKey(keyShift)
-		sequencer = cipher.NewCaesarSequencer(p)
+	// I. Select parameters, create & validate sequencer
+	sequencer, err := cc.newSequencer("decryptor", mode, keyShift, args...)
+	if err != nil {
+		return "", err
+	}
+	logx.Printf("Decrypt %s sequencer validated", sequencer)
 
-	case DidimusMode:
-		if len(args) != 1 {
-			return "", errors.New("missing Offset parameter to Didimus decryptor")
-		}
-		if ofs, ok := (args[0]).(int); ok {
-			p.SetKey(keyShift)
-			p.SetAltKeyOffset(ofs)
-			sequencer = cipher.NewDidimusSequencer(p)
-		} else {
-			return "", errors.New("invalid parameter type to Didimus")
-		}
+	// II. Decrypt the (ungrouped) ciphertext
+	caesarHandler := cipher.NewCaesarCipherFromSequencer(sequencer)
+	normalizer := NewTextNormalizer(cc.alpha, cc.normalization).PairWith(cc.symbols)
+	if normalizer.CanGroup() {
+		ciphered = normalizer.Ungroup(ciphered)
+	}
 
-	case FibonacciMode:
-		p.SetKey(keyShift)
-		sequencer = cipher.NewFibonacciSequencer(p)
+	return caesarHandler.Decode(ciphered), nil
+}
 
-	case PrimusMode:
-		p.SetKey(keyShift)
-		sequencer = cipher.NewPrimusSequencer(p)
+// Decrypts the fragment [from,to) (rune offsets) of a longer ciphered
+// message, e.g. a selected region, without decrypting what precedes it.
+// A grouped ciphertext is ungrouped as in Decrypt(). The extra arguments
+// are the same as for Encrypt().
+func (cc *CipherController) DecryptFragment(mode CaesarCipherMode, message string, from, to int, keyShift int, args ...any) (string, error) {
+	logx.Enter()
+	defer logx.Leave()
 
-	default:
-		return "", errors.New("invalid cipher mode given to controller")
+	runic := []rune(message)
+	if from < 0 || to > len(runic) || from > to {
+		return "", fmt.Errorf("invalid fragment [%d,%d) of a %d-character message", from, to, len(runic))
 	}
 
-	// II. Validate parameters via sequencer
-	if err := sequencer.Validate(); err != nil {
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
+
+	sequencer, err := cc.newSequencer("decryptor", mode, keyShift, args...)
+	if err != nil {
 		return "", err
 	}
-	logx.Printf("Decrypt %s sequencer validated", sequencer)
-	// III. Decrypt
-	caesarHandler := cipher.NewCaesarCipherFromSequencer(sequencer)
 
-	return caesarHandler.Decode(ciphered), nil
+	// · the grouping of the ciphertext is removed as in Decrypt()
+	preceding, fragment := string(runic[:from]), string(runic[from:to])
+	normalizer := NewTextNormalizer(cc.alpha, cc.normalization).PairWith(cc.symbols)
+	if normalizer.CanGroup() {
+		preceding, fragment = normalizer.Ungroup(preceding), normalizer.Ungroup(fragment)
+	}
+
+	// · every (composed) character counts when keying them all, except
+	//   for Autokey where only the transcoded characters take keys
+	position := utf8.RuneCountInString(cc.alpha.Normalize(preceding))
+	if _, isAutokey := sequencer.(cipher.IFeedbackSequencer); isAutokey || !cc.keying.KeysForeign() {
+		position = cipher.EncodeablePosition(preceding, utf8.RuneCountInString(preceding), cc.alpha, cc.symbols)
+	}
+	return cipher.NewCaesarCipherFromSequencer(sequencer).DecodeAt(fragment, position)
+}
+
+// A streaming encryptor. Everything written to the returned writer is
+// encrypted into w with the same parameters as Encrypt(), the key
+// sequence continues across writes. It must be closed to flush any
+// trailing incomplete UTF-8 sequence, w itself is not closed.
+func (cc *CipherController) NewEncryptingWriter(mode CaesarCipherMode, w io.Writer, keyShift int, args ...any) (io.WriteCloser, error) {
+	logx.Enter()
+	defer logx.Leave()
+
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
+
+	sequencer, err := cc.newSequencer("encryptor", mode, keyShift, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewEncryptingWriter(w, sequencer), nil
+}
+
+// A streaming decryptor. The returned reader decrypts what it reads
+// from r with the same parameters as Decrypt().
+func (cc *CipherController) NewDecryptingReader(mode CaesarCipherMode, r io.Reader, keyShift int, args ...any) (io.Reader, error) {
+	logx.Enter()
+	defer logx.Leave()
+
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
+
+	sequencer, err := cc.newSequencer("decryptor", mode, keyShift, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewDecryptingReader(r, sequencer), nil
 }
 
 // takes an already encrypted string and packages it in a PDU that can
@@ -202,22 +387,16 @@ func (cc *CipherController) PackMessage(cipherPayload string) string {
 
 // takes a PDU that contains an encrypted message from a communications
 // channel and unpacks it to verify the metadata and if successful,
-// return the decrypted payload.
-func (cc *CipherController) UnpackMessage(pdu string, mode CaesarCipherMode, keyShift int, keyOffset int) (string, error) {
+// return the decrypted payload. The extra arguments are the same as
+// for Encrypt().
+func (cc *CipherController) UnpackMessage(pdu string, mode CaesarCipherMode, keyShift int, args ...any) (string, error) {
 	logx.Enter()
 	defer logx.Leave()
 
 	check := hash.NewXXH64(hashSeed)
 	payload, err := cipher.VerifyCaesarMessage(check, pdu)
 	if err == nil {
-		var plain string
-		if mode != DidimusMode {
-			plain, err = cc.Decrypt(mode, payload, keyShift)
-		} else {
-			plain, err = cc.Decrypt(mode, payload, keyShift, keyOffset)
-		}
-
-		return plain, err
+		return cc.Decrypt(mode, payload, keyShift, args...)
 	}
 
 	return "", err
@@ -234,22 +413,45 @@ func (cc *CipherController) CaesarCorrection(keyShift int) (main CaesarKey, warn
 	}, warn
 }
 
-// The key schedule for a plain Caesar scheduler
-func (cc *CipherController) GetCaesarSchedule(keyShift int) (KeySchedule, error) {
-	// parameters for the sequencer
-	p := cipher.NewCaesarParameters(cc.alpha)
-	p.KeyValue = keyShift
+// proxies the Affine multiplier corrector for the current alphabet
+func (cc *CipherController) AffineCorrection(multiplier int) (mult int, warn error) {
+	return cipher.AffineCorrection(multiplier, cc.alpha)
+}
+
+// the valid range of the Offset of the cipher mode, which is the key
+// range of the alphabet unless the mode registered its own. That one
+// is called without holding the lock, so it may use the getters.
+func (cc *CipherController) OffsetRange(mode CaesarCipherMode) (min, max int) {
+	spec, err := LookupCipherMode(mode)
+	if err == nil && spec.OffsetRange != nil {
+		return spec.OffsetRange(cc)
+	}
+	return 0, cc.alpha.Length() - 1
+}
+
+// The key schedule of any registered cipher mode. The extra arguments
+// are the same as for Encrypt(). Invalid keys are corrected as usual,
+// only a mode without any key (e.g. a Vigenère keyword without letters
+// of the alphabet) has no schedule.
+func (cc *CipherController) GetKeySchedule(mode CaesarCipherMode, keyShift int, args ...any) (KeySchedule, error) {
+	cc.mutexRW.Lock()
+	defer cc.mutexRW.Unlock()
+
 	// the sequencer that will provide us the raw sequence of keys
-	seq := cipher.NewCaesarSequencer(p)
-	if seq.Validate() != nil {
-		// obtain corrected parameters.
-		p = seq.GetParams()
+	seq, err := cc.prepareSequencer("schedule", mode, keyShift, args...)
+	if err != nil {
+		return nil, err
 	}
+	warn := seq.Validate()
 	// get the raw key schedule
 	rawSchedule := seq.GetRawKeySchedule()
+	if len(rawSchedule) == 0 && warn != nil {
+		return nil, warn
+	}
+	// Affine & Atbash have their own tabula
+	tabulator, hasTabula := seq.(cipher.ITabulaSequencer)
 	// convert it to a public API object
-	qty := len(rawSchedule)
-	schedule := make(KeySchedule, qty)
+	schedule := make(KeySchedule, len(rawSchedule))
 	for i, raw := range rawSchedule {
 		shf := raw.KeyShift
 		chr, _ := cc.alpha.Character(shf)
@@ -257,105 +459,120 @@ func (cc *CipherController) GetCaesarSchedule(keyShift int) (KeySchedule, error)
 			KeyShift: shf,
 			KeyChar:  chr,
 			Comment:  raw.Comment,
-			Tabula:   cipher.RotateStringLeft(cc.alpha.String(), shf),
+		}
+		if hasTabula {
+			schedule[i].Tabula = tabulator.Tabula(cc.cipherAlphabet().String(), shf)
+		} else {
+			schedule[i].Tabula = cipher.CipherTabula(cc.cipherAlphabet().String(), shf, cc.direction)
 		}
 	}
 	return schedule, nil
 }
 
+// The key schedule for a plain Caesar scheduler
+func (cc *CipherController) GetCaesarSchedule(keyShift int) (KeySchedule, error) {
+	return cc.GetKeySchedule(CaesarMode, keyShift)
+}
+
 // The key schedule for a Didimus scheduler
 func (cc *CipherController) GetDidimusSchedule(keyShift, keyOffset int) (KeySchedule, error) {
-	// parameters for the sequencer
-	p := cipher.NewCaesarParameters(cc.alpha)
-	p.KeyValue = keyShift
-	p.Offset = keyOffset
-	// the sequencer that will provide us the raw sequence of keys
-	seq := cipher.NewDidimusSequencer(p)
-	if seq.Validate() != nil {
-		// obtain corrected parameters.
-		p = seq.GetParams()
-	}
-	// get the raw key schedule
-	rawSchedule := seq.GetRawKeySchedule()
-	// convert it to a public API object
-	qty := len(rawSchedule)
-	schedule := make(KeySchedule, qty)
-	for i, raw := range rawSchedule {
-		shf := raw.KeyShift
-		chr, _ := cc.alpha.Character(shf)
-		schedule[i] = KeyScheduleItem{
-			KeyShift: shf,
-			KeyChar:  chr,
-			Comment:  raw.Comment,
-			Tabula:   cipher.RotateStringLeft(cc.alpha.String(), shf),
-		}
-	}
-	return schedule, nil
+	return cc.GetKeySchedule(DidimusMode, keyShift, keyOffset)
 }
 
-// The key schedule for a Fibonacci scheduler
-func (cc *CipherController) GetFibonacciSchedule(keyShift int) (KeySchedule, error) {
-	// parameters for the sequencer
-	p := cipher.NewCaesarParameters(cc.alpha)
-	p.KeyValue = keyShift
-	// the sequencer that will provide us the raw sequence of keys
-	seq := cipher.NewFibonacciSequencer(p)
-	if seq.Validate() != nil {
-		// obtain corrected parameters.
-		p = seq.GetParams()
-	}
-	// get the raw key schedule
-	rawSchedule := seq.GetRawKeySchedule()
-	// convert it to a public API object
-	qty := len(rawSchedule)
-	schedule := make(KeySchedule, qty)
-	for i, raw := range rawSchedule {
-		shf := raw.KeyShift
-		chr, _ := cc.alpha.Character(shf)
-		schedule[i] = KeyScheduleItem{
-			KeyShift: shf,
-			KeyChar:  chr,
-			Comment:  raw.Comment,
-			Tabula:   cipher.RotateStringLeft(cc.alpha.String(), shf),
-		}
+// The key schedule for a Fibonacci scheduler. The optional series
+// overrides the default 10-term Fibonacci series.
+func (cc *CipherController) GetFibonacciSchedule(keyShift int, series ...FibonacciSeries) (KeySchedule, error) {
+	if len(series) > 0 {
+		return cc.GetKeySchedule(FibonacciMode, keyShift, series[0])
 	}
-	return schedule, nil
+	return cc.GetKeySchedule(FibonacciMode, keyShift)
 }
 
 // The key schedule for a Primus scheduler
 func (cc *CipherController) GetPrimusSchedule(keyShift, keyOffset int) (KeySchedule, error) {
-	// parameters for the sequencer
-	p := cipher.NewCaesarParameters(cc.alpha)
-	p.KeyValue = keyShift
-	p.Offset = keyOffset
-	// the sequencer that will provide us the raw sequence of keys
-	seq := cipher.NewPrimusSequencer(p)
-	if seq.Validate() != nil {
-		// obtain corrected parameters.
-		p = seq.GetParams()
-	}
-	// get the raw key schedule
-	rawSchedule := seq.GetRawKeySchedule()
-	// convert it to a public API object
-	qty := len(rawSchedule)
-	schedule := make(KeySchedule, qty)
-	for i, raw := range rawSchedule {
-		shf := raw.KeyShift
-		chr, _ := cc.alpha.Character(shf)
-		schedule[i] = KeyScheduleItem{
-			KeyShift: shf,
-			KeyChar:  chr,
-			Comment:  raw.Comment,
-			Tabula:   cipher.RotateStringLeft(cc.alpha.String(), shf),
-		}
-	}
-	return schedule, nil
+	return cc.GetKeySchedule(PrimusMode, keyShift, keyOffset)
+}
+
+// The key schedule for a Vigenère scheduler
+func (cc *CipherController) GetVigenereSchedule(keyword string) (KeySchedule, error) {
+	return cc.GetKeySchedule(VigenereMode, 0, keyword)
+}
+
+// The key schedule for an Autokey scheduler. Only the primer is
+// known in advance, the rest of the schedule is the message itself.
+func (cc *CipherController) GetAutokeySchedule(keyShift int, primer string) (KeySchedule, error) {
+	return cc.GetKeySchedule(AutokeyMode, keyShift, primer)
+}
+
+// The key schedule for an Affine scheduler. Its single entry shows
+// the whole affine tabula for multiplier a and additive key b.
+func (cc *CipherController) GetAffineSchedule(keyShift, multiplier int) (KeySchedule, error) {
+	return cc.GetKeySchedule(AffineMode, keyShift, multiplier)
+}
+
+// The key schedule for an Atbash scheduler. Its single entry shows
+// the reflected tabula turned by the main key.
+func (cc *CipherController) GetAtbashSchedule(keyShift int) (KeySchedule, error) {
+	return cc.GetKeySchedule(AtbashMode, keyShift)
+}
+
+// A preview of the key schedule for a Xorshift scheduler. The key
+// stream practically never repeats so only the first N keys are given.
+func (cc *CipherController) GetXorshiftSchedule(keyShift, keyOffset int, passphrase string) (KeySchedule, error) {
+	return cc.GetKeySchedule(XorshiftMode, keyShift, keyOffset, passphrase)
 }
 
 /* ----------------------------------------------------------------
  *				P r i v a t e	M e t h o d s
  *-----------------------------------------------------------------*/
 
+// creates the key sequencer for the cipher mode with its parameters
+// (see Encrypt) and validates it. The role (encryptor/decryptor) is
+// only used in error messages. The caller must hold the lock.
+func (cc *CipherController) newSequencer(role string, mode CaesarCipherMode, keyShift int, args ...any) (cipher.IKeySequencer, error) {
+	sequencer, err := cc.prepareSequencer(role, mode, keyShift, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate parameters via sequencer
+	if err := sequencer.Validate(); err != nil {
+		return nil, err
+	}
+
+	return sequencer, nil
+}
+
+// the alphabet of the cipher (inner) ring. The caller must hold the lock.
+func (cc *CipherController) cipherAlphabet() *caesardisk.AlphabetModel {
+	if cc.mixed != nil {
+		return cc.mixed
+	}
+	return cc.alpha
+}
+
+// creates the (not yet validated) key sequencer of a registered cipher
+// mode with the settings of the controller. The caller must hold the lock.
+func (cc *CipherController) prepareSequencer(role string, mode CaesarCipherMode, keyShift int, args ...any) (cipher.IKeySequencer, error) {
+	spec, err := LookupCipherMode(mode)
+	if err != nil {
+		return nil, errors.New("invalid cipher mode given to controller")
+	}
+
+	opts, err := spec.options(role, keyShift, args...)
+	if err != nil {
+		return nil, err
+	}
+	window := cc.primeWindow
+	opts.Direction = cc.direction
+	opts.Keying = cc.keying
+	opts.MixKeyword = cc.mixKeyword
+	opts.Symbols = cc.symbols
+	opts.Primes = &window
+
+	return engine.PrepareSequencer(engine.Mode(spec.Name), cc.alpha, opts)
+}
+
 /* ----------------------------------------------------------------
  *					F u n c t i o n s
  *-----------------------------------------------------------------*/
@@ -0,0 +1,75 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   APP_NAME
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Derivation of the key material from a memorable passphrase so that
+ * correspondents need not exchange raw shift numbers. The derivation
+ * is deterministic and depends on the alphabet: the same passphrase
+ * gives the same keys on the same alphabet, but (most likely) other
+ * keys on another alphabet.
+ *-----------------------------------------------------------------*/
+package crypto
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/lordofscripts/caesardisk"
+	"github.com/lordofscripts/caesardisk/internal/cipher"
+	"github.com/lordofscripts/caesardisk/internal/hash"
+)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// The key material derived from a passphrase. MainKey applies to all
+// modes, Offset (and thus AltKey) to those that use it, and MixKeyword
+// to keyed modes via CipherController.SetMixedAlphabet().
+type PassphraseKey struct {
+	MainKey    CaesarKey
+	AltKey     CaesarKey
+	Offset     int
+	MixKeyword string
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+// Derives the main key, offset and mixed alphabet keyword from a
+// passphrase. Letter case and extra white space in the passphrase
+// are ignored. The XXH64 hash of the alphabet and the passphrase is
+// split into the main key and offset, both in 1..N-1 since zero does
+// not encrypt. DidimusCorrection then normalizes the alternate key.
+func DeriveKeyFromPassphrase(passphrase string, alpha *caesardisk.AlphabetModel) (PassphraseKey, error) {
+	normalized := strings.ToUpper(strings.Join(strings.Fields(passphrase), " "))
+	if len(normalized) == 0 {
+		return PassphraseKey{}, errors.New("empty passphrase")
+	}
+
+	h := hash.NewXXH64(hashSeed)
+	h.Update([]byte(alpha.String()))
+	h.Update([]byte{0})
+	h.Update([]byte(normalized))
+	digest := h.Digest()
+
+	// I. the main key is never zero, so 1..N-1
+	N := alpha.Length()
+	main := int(digest>>32&0xFFFF)%(N-1) + 1
+	// II. the offset is also 1..N-1, the alternate key is never zero
+	_, ofs, alt, _ := cipher.DidimusCorrection(main, int(digest&0xFFFF)%(N-1)+1, alpha)
+
+	key := PassphraseKey{
+		MainKey: CaesarKey{Shift: main},
+		AltKey:  CaesarKey{Shift: alt},
+		Offset:  ofs,
+	}
+	key.MainKey.Letter, _ = alpha.Character(main)
+	key.AltKey.Letter, _ = alpha.Character(alt)
+	// III. the keyword for the mixed cipher ring is the passphrase
+	key.MixKeyword = normalized
+
+	return key, nil
+}
@@ -0,0 +1,229 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Optional preprocessing of the plain text prior to encryption. Any
+ * character that is not in the alphabet passes through the cipher
+ * unchanged, therefore accented letters, word boundaries & punctuation
+ * would be visible in the ciphertext. The normalizer transliterates
+ * accented letters to the base letters of the alphabet, folds the
+ * German sharp S, strips spaces & punctuation and finally groups the
+ * ciphertext in classic blocks (usually of five letters).
+ * Characters that belong to the alphabet are never altered.
+ *-----------------------------------------------------------------*/
+package crypto
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/lordofscripts/caesardisk"
+	"golang.org/x/text/unicode/norm"
+)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+const (
+	sharpS        rune = 'ß'
+	capitalSharpS rune = 'ẞ'
+)
+
+// the traditional preparation of a message: no accents, no spaces,
+// no punctuation and the ciphertext in blocks of five letters.
+var ClassicNormalization NormalizerOptions = NormalizerOptions{
+	Transliterate:    true,
+	FoldSharpS:       true,
+	StripSpaces:      true,
+	StripPunctuation: true,
+	GroupSize:        5,
+}
+
+// letters that do not decompose into a base letter plus diacritics
+var ligatures map[rune]string = map[rune]string{
+	'Æ': "AE", 'æ': "ae",
+	'Œ': "OE", 'œ': "oe",
+	'Ø': "O", 'ø': "o",
+	'Ł': "L", 'ł': "l",
+	'Đ': "D", 'đ': "d",
+	'Þ': "TH", 'þ': "th",
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// The preprocessing steps of a TextNormalizer. The zero value leaves
+// the text untouched.
+type NormalizerOptions struct {
+	// letters not in the alphabet are replaced by their base letters,
+	// i.e. Á becomes A in the English alphabet but not in Czech.
+	Transliterate bool
+	// ß becomes SS unless the alphabet has ẞ
+	FoldSharpS bool
+	// remove white space (unless in the alphabet)
+	StripSpaces bool
+	// remove punctuation & symbols (unless in the alphabet)
+	StripPunctuation bool
+	// the ciphertext is written in blocks of this many characters
+	// separated by a space, zero for none. It implies StripSpaces.
+	GroupSize int
+}
+
+// A plain text normalizer & ciphertext grouper for an alphabet.
+type TextNormalizer struct {
+	alpha   *caesardisk.AlphabetModel
+	symbols *caesardisk.AlphabetModel // of a dual disk (optional)
+	opts    NormalizerOptions
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// (ctor) a text normalizer with the given options for the alphabet.
+func NewTextNormalizer(alpha *caesardisk.AlphabetModel, opts NormalizerOptions) *TextNormalizer {
+	return &TextNormalizer{
+		alpha: alpha,
+		opts:  opts,
+	}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// whether the options have any effect at all
+func (o NormalizerOptions) IsEnabled() bool {
+	return o != NormalizerOptions{}
+}
+
+// the paired symbols of a dual disk are encrypted, so they are never
+// stripped from the plain text. Use nil for a single alphabet.
+func (tn *TextNormalizer) PairWith(symbols *caesardisk.AlphabetModel) *TextNormalizer {
+	tn.symbols = symbols
+	return tn
+}
+
+// whether the ciphertext may be grouped. It cannot when the alphabets
+// contain white space, since the groups are separated by spaces.
+func (tn *TextNormalizer) CanGroup() bool {
+	if tn.opts.GroupSize < 1 {
+		return true
+	}
+
+	return !tn.inAlphabet(' ')
+}
+
+// prepares the plain text for encryption
+func (tn *TextNormalizer) Normalize(plain string) string {
+	var sb strings.Builder
+	for _, char := range tn.alpha.Normalize(plain) {
+		if tn.inAlphabet(char) {
+			sb.WriteRune(char)
+			continue
+		}
+
+		switch {
+		case unicode.IsSpace(char):
+			if !tn.opts.StripSpaces && tn.opts.GroupSize == 0 {
+				sb.WriteRune(char)
+			}
+		case unicode.IsPunct(char) || unicode.IsSymbol(char):
+			if !tn.opts.StripPunctuation {
+				sb.WriteRune(char)
+			}
+		case tn.opts.FoldSharpS && (char == sharpS || char == capitalSharpS):
+			sb.WriteString(tn.foldSharpS(char))
+		case tn.opts.Transliterate:
+			sb.WriteString(tn.transliterate(char))
+		default:
+			sb.WriteRune(char)
+		}
+	}
+
+	return sb.String()
+}
+
+// writes the ciphertext in blocks of GroupSize characters. All white
+// space of the ciphertext is discarded beforehand.
+func (tn *TextNormalizer) Group(ciphered string) string {
+	if tn.opts.GroupSize < 1 {
+		return ciphered
+	}
+
+	var sb strings.Builder
+	for i, char := range []rune(tn.Ungroup(ciphered)) {
+		if i > 0 && i%tn.opts.GroupSize == 0 {
+			sb.WriteRune(' ')
+		}
+		sb.WriteRune(char)
+	}
+
+	return sb.String()
+}
+
+// removes the grouping (white space) of the ciphertext
+func (tn *TextNormalizer) Ungroup(ciphered string) string {
+	if tn.opts.GroupSize < 1 {
+		return ciphered
+	}
+
+	return strings.Join(strings.Fields(ciphered), "")
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// whether the character is encrypted with the alphabet or the symbols
+func (tn *TextNormalizer) inAlphabet(char rune) bool {
+	char = caesardisk.UpperCase(char)
+	if tn.symbols != nil && tn.symbols.FindExact(char) != -1 {
+		return true
+	}
+	return tn.alpha.FindExact(char) != -1
+}
+
+// ß & ẞ to the sharp S of the alphabet, else to ss/SS in the case of
+// the text. Only an upper-case alphabet turns ß into ẞ, otherwise the
+// case is kept or folded to the one sharp S the alphabet has.
+func (tn *TextNormalizer) foldSharpS(char rune) string {
+	switch {
+	case tn.alpha.FindExact(char) != -1:
+		return string(char)
+	case tn.alpha.IsUpper() && tn.alpha.FindExact(capitalSharpS) != -1:
+		return string(capitalSharpS)
+	case tn.alpha.FindExact(sharpS) != -1:
+		return string(sharpS)
+	case char == capitalSharpS:
+		return "SS"
+	}
+	return "ss"
+}
+
+// the base letters of an accented letter or ligature provided they
+// are in the alphabet, else the letter itself.
+func (tn *TextNormalizer) transliterate(char rune) string {
+	base, ok := ligatures[char]
+	if !ok {
+		// · canonical decomposition without the combining marks
+		var sb strings.Builder
+		for _, part := range norm.NFD.String(string(char)) {
+			if !unicode.Is(unicode.Mn, part) {
+				sb.WriteRune(part)
+			}
+		}
+		base = sb.String()
+	}
+
+	for _, part := range base {
+		if !tn.inAlphabet(part) {
+			return string(char)
+		}
+	}
+
+	return base
+}
@@ -0,0 +1,330 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * The public & stable API of the Caesar-class cipher engine for those
+ * who want to embed it in their own tools. Unlike CipherController it
+ * takes typed Options rather than variadic arguments, exposes the key
+ * sequencers and lets applications register their own cipher modes.
+ *
+ *	alpha := caesardisk.AlphabetFactory("EN")
+ *	caesar, err := engine.New(engine.VigenereMode, alpha, engine.Options{Keyword: "LEMON"})
+ *	ciphered := caesar.Encode("Attack at dawn")
+ *-----------------------------------------------------------------*/
+package engine
+
+import (
+	"io"
+
+	"github.com/lordofscripts/caesardisk"
+	"github.com/lordofscripts/caesardisk/internal/cipher"
+	"github.com/lordofscripts/caesardisk/internal/hash"
+)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+const (
+	// C = P + K the usual "shift forward" Caesar
+	StandardDirection Direction = cipher.StandardDirection
+	// C = K - P which is reciprocal (encryption same as decryption)
+	BeaufortDirection Direction = cipher.BeaufortDirection
+	// C = P - K
+	VariantBeaufortDirection Direction = cipher.VariantBeaufortDirection
+)
+
+const (
+	// only alphabet characters advance the key (default)
+	SkipForeignKeying KeyingPolicy = cipher.SkipForeignKeying
+	// every character, including spaces & punctuation, advances the key
+	CountAllKeying KeyingPolicy = cipher.CountAllKeying
+)
+
+// the seed of the PDU checksum, same as CipherController's
+const pduHashSeed uint64 = 0xDEADBEA7
+
+// reported by the modes with their own tabula (Affine, Atbash) when
+// not in the standard direction
+var ErrDirectionUnsupported error = cipher.ErrDirectionUnsupported
+
+// the classic 10-term Fibonacci series {0,1,1,2,3,5,8,13,21,34}
+var DefaultFibonacciSeries FibonacciSeries = cipher.DefaultFibonacciSeries
+
+// the first 11 primes {2,3,5,...,31}
+var DefaultPrimeWindow PrimeWindow = cipher.DefaultPrimeWindow
+
+/* ----------------------------------------------------------------
+ *				I n t e r f a c e s
+ *-----------------------------------------------------------------*/
+
+// The key sequencer of a cipher mode. It provides the key shift of
+// every encodeable character of the message. Custom sequencers may
+// embed BaseSequencer and override what they need.
+type IKeySequencer = cipher.IKeySequencer
+
+// A key sequencer whose keys depend on the transcoded text (Autokey)
+type IFeedbackSequencer = cipher.IFeedbackSequencer
+
+// A key sequencer with its own tabula (Affine, Atbash)
+type ITabulaSequencer = cipher.ITabulaSequencer
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// The Caesar-class transcoder driven by a key sequencer
+type Caesar = cipher.Caesar
+
+// The parameters a key sequencer is built with
+type Parameters = cipher.CaesarParameters
+
+// The plain Caesar sequencer (same key throughout the message) that
+// custom sequencers may embed. A custom sequencer must override
+// Clone(), the inherited one panics rather than hand out a plain Caesar
+// copy. One whose key varies must also override IsPolyalphabetic(),
+// else the main key is used throughout the message.
+type BaseSequencer struct {
+	cipher.CaesarSequencer
+}
+
+// The operation combining plain character and key shift
+type Direction = cipher.Direction
+
+// Which characters of the message advance the key sequence
+type KeyingPolicy = cipher.KeyingPolicy
+
+// The length and seeds of the Fibonacci series of FibonacciMode
+type FibonacciSeries = cipher.FibonacciSeries
+
+// The window of consecutive primes of PrimusMode
+type PrimeWindow = cipher.PrimeWindow
+
+// A single entry of the raw key schedule of a sequencer
+type KeyScheduleItem = cipher.KeyScheduleItemInt
+
+// The typed options of a cipher mode. Each mode uses only the options
+// it needs, the rest are ignored.
+type Options struct {
+	// the main key shift (all modes but Vigenère)
+	Key int
+	// Didimus offset, Primus number of primes (zero for all of the
+	// window) & Xorshift seed. CipherController also defaults to zero.
+	Offset int
+	// Affine multiplier, must be coprime with the alphabet length
+	Multiplier int
+	// Vigenère keyword, Autokey primer & Xorshift passphrase
+	Keyword string
+	// Standard (default), Beaufort or Variant Beaufort
+	Direction Direction
+	// whether foreign characters advance the key
+	Keying KeyingPolicy
+	// keyword of the mixed alphabet of the cipher ring (optional)
+	MixKeyword string
+	// the paired symbols of a dual disk (optional)
+	Symbols *caesardisk.AlphabetModel
+	// the Fibonacci series, nil for DefaultFibonacciSeries
+	Fibonacci *FibonacciSeries
+	// the window of primes, nil for DefaultPrimeWindow
+	Primes *PrimeWindow
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// (ctor) a Caesar-class transcoder of a registered cipher mode with
+// validated parameters.
+func New(mode Mode, alpha *caesardisk.AlphabetModel, opts Options) (*Caesar, error) {
+	sequencer, err := NewSequencer(mode, alpha, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewCaesarCipherFromSequencer(sequencer), nil
+}
+
+// (ctor) a plain Caesar transcoder with the main key
+func NewCaesar(alpha *caesardisk.AlphabetModel, key int) (*Caesar, error) {
+	return New(CaesarMode, alpha, Options{Key: key})
+}
+
+// (ctor) a Didimus transcoder, the offset gives the key of odd letters
+func NewDidimus(alpha *caesardisk.AlphabetModel, key, offset int) (*Caesar, error) {
+	return New(DidimusMode, alpha, Options{Key: key, Offset: offset})
+}
+
+// (ctor) a Fibonacci transcoder with DefaultFibonacciSeries
+func NewFibonacci(alpha *caesardisk.AlphabetModel, key int) (*Caesar, error) {
+	return New(FibonacciMode, alpha, Options{Key: key})
+}
+
+// (ctor) a Primus transcoder with DefaultPrimeWindow, primes is the
+// number of primes used (zero for all of the window).
+func NewPrimus(alpha *caesardisk.AlphabetModel, key, primes int) (*Caesar, error) {
+	return New(PrimusMode, alpha, Options{Key: key, Offset: primes})
+}
+
+// (ctor) a Vigenère transcoder with the keyword
+func NewVigenere(alpha *caesardisk.AlphabetModel, keyword string) (*Caesar, error) {
+	return New(VigenereMode, alpha, Options{Keyword: keyword})
+}
+
+// (ctor) an Autokey transcoder with the primer. The key stream is fed
+// with the plain text, or with the cipher text if cipherFeedback.
+func NewAutokey(alpha *caesardisk.AlphabetModel, primer string, cipherFeedback bool) (*Caesar, error) {
+	mode := AutokeyMode
+	if cipherFeedback {
+		mode = AutokeyCipherMode
+	}
+	return New(mode, alpha, Options{Keyword: primer})
+}
+
+// (ctor) an Affine transcoder E(x) = (a*x + b) mod N, where the
+// multiplier a must be coprime with the alphabet length & b is the key.
+func NewAffine(alpha *caesardisk.AlphabetModel, key, multiplier int) (*Caesar, error) {
+	return New(AffineMode, alpha, Options{Key: key, Multiplier: multiplier})
+}
+
+// (ctor) an Atbash transcoder (the reversed alphabet)
+func NewAtbash(alpha *caesardisk.AlphabetModel) (*Caesar, error) {
+	return New(AtbashMode, alpha, Options{})
+}
+
+// (ctor) a Xorshift transcoder whose key stream is seeded with the
+// main key, the seed & the passphrase.
+func NewXorshift(alpha *caesardisk.AlphabetModel, key, seed int, passphrase string) (*Caesar, error) {
+	return New(XorshiftMode, alpha, Options{Key: key, Offset: seed, Keyword: passphrase})
+}
+
+// (ctor) a Caesar-class transcoder of a custom key sequencer, which
+// must have been validated.
+func NewFromSequencer(seq IKeySequencer) *Caesar {
+	return cipher.NewCaesarCipherFromSequencer(seq)
+}
+
+// (ctor) the validated key sequencer of a registered cipher mode. An
+// invalid parameter (e.g. a zero key) is reported as an error.
+func NewSequencer(mode Mode, alpha *caesardisk.AlphabetModel, opts Options) (IKeySequencer, error) {
+	sequencer, err := PrepareSequencer(mode, alpha, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sequencer.Validate(); err != nil {
+		return nil, err
+	}
+
+	return sequencer, nil
+}
+
+// (ctor) the key sequencer of a registered cipher mode, not validated
+// yet. Validate() corrects the parameters & reports what it corrected,
+// which is handy for key schedules that tolerate corrected keys.
+func PrepareSequencer(mode Mode, alpha *caesardisk.AlphabetModel, opts Options) (IKeySequencer, error) {
+	factory, err := lookup(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := opts.parameters(alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	return factory(p, opts), nil
+}
+
+// (ctor) the parameters of a custom sequencer for the alphabet
+func NewParameters(alpha *caesardisk.AlphabetModel) *Parameters {
+	return cipher.NewCaesarParameters(alpha)
+}
+
+// (ctor) the base sequencer that custom sequencers may embed
+func NewBaseSequencer(p *Parameters) *BaseSequencer {
+	return &BaseSequencer{CaesarSequencer: *cipher.NewCaesarSequencer(p)}
+}
+
+// (ctor) a writer that encrypts to w with a validated sequencer. It
+// must be closed to flush the last characters, w is not closed.
+func NewEncryptingWriter(w io.Writer, seq IKeySequencer) io.WriteCloser {
+	return cipher.NewEncryptingWriter(w, seq)
+}
+
+// (ctor) a writer that decrypts to w with a validated sequencer. It
+// must be closed to flush the last characters, w is not closed.
+func NewDecryptingWriter(w io.Writer, seq IKeySequencer) io.WriteCloser {
+	return cipher.NewDecryptingWriter(w, seq)
+}
+
+// (ctor) a reader that encrypts what it reads from r
+func NewEncryptingReader(r io.Reader, seq IKeySequencer) io.Reader {
+	return cipher.NewEncryptingReader(r, seq)
+}
+
+// (ctor) a reader that decrypts what it reads from r
+func NewDecryptingReader(r io.Reader, seq IKeySequencer) io.Reader {
+	return cipher.NewDecryptingReader(r, seq)
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// Clone() is part of the contract of custom sequencers, the base cannot
+// copy the state of the type that embeds it.
+func (bs *BaseSequencer) Clone() IKeySequencer {
+	panic("engine: a custom sequencer embedding BaseSequencer must override Clone()")
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// the parameters common to all modes, the mode-specific ones are set
+// by the sequencer factory.
+func (o Options) parameters(alpha *caesardisk.AlphabetModel) (*Parameters, error) {
+	p := cipher.NewCaesarParameters(alpha)
+	p.SetKey(o.Key)
+	p.Direction = o.Direction
+	p.Keying = o.Keying
+	if len(o.MixKeyword) != 0 {
+		p.CipherAlphabet = caesardisk.NewKeyedAlphabet(alpha, o.MixKeyword)
+		if !p.CipherAlphabet.IsPermutationOf(alpha) {
+			return nil, caesardisk.ErrNotPermutation
+		}
+	}
+	if o.Symbols != nil {
+		if o.Symbols.Length() != alpha.Length() {
+			return nil, caesardisk.ErrUnmatchedDual
+		}
+		p.Symbols = o.Symbols
+	}
+
+	return p, nil
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+// packages an encrypted message in the standard PDU format
+// {TIMESTAMP}{CHECKSUM}{PAYLOAD} used by CipherController.
+func PackMessage(ciphered string) string {
+	msgPDU := cipher.NewCaesarMessage(hash.NewXXH64(pduHashSeed))
+	msgPDU.AddMessage(ciphered)
+
+	return msgPDU.String()
+}
+
+// verifies the checksum of a PDU and returns its (encrypted) payload
+func UnpackMessage(pdu string) (string, error) {
+	return cipher.VerifyCaesarMessage(hash.NewXXH64(pduHashSeed), pdu)
+}
+
+// the number of encodeable characters that precede runeOffset in the
+// text, i.e. the position to give to Caesar.DecodeAt().
+func EncodeablePosition(text string, runeOffset int, alpha *caesardisk.AlphabetModel, paired ...*caesardisk.AlphabetModel) int {
+	return cipher.EncodeablePosition(text, runeOffset, alpha, paired...)
+}
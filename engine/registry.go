@@ -0,0 +1,157 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * The registry of cipher modes. Each mode has a factory that creates
+ * its key sequencer from the common parameters & the typed options.
+ * The built-in modes are registered at start-up, applications can
+ * register their own with Register().
+ *-----------------------------------------------------------------*/
+package engine
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/lordofscripts/caesardisk/internal/cipher"
+)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+const (
+	CaesarMode        Mode = "Caesar"
+	DidimusMode       Mode = "Didimus"
+	FibonacciMode     Mode = "Fibonacci"
+	PrimusMode        Mode = "Primus"
+	VigenereMode      Mode = "Vigenère"
+	AutokeyMode       Mode = "Autokey"              // plaintext feedback
+	AutokeyCipherMode Mode = "Autokey (ciphertext)" // ciphertext feedback
+	AffineMode        Mode = "Affine"
+	AtbashMode        Mode = "Atbash"
+	XorshiftMode      Mode = "Xorshift" // seeded PRNG key stream
+)
+
+var registry = struct {
+	sync.RWMutex
+	factories map[Mode]SequencerFactory
+	modes     []Mode // in order of registration
+}{
+	factories: make(map[Mode]SequencerFactory),
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// The name of a registered cipher mode
+type Mode string
+
+// Creates the (not yet validated) key sequencer of a cipher mode. The
+// parameters already hold the alphabet, main key, direction, keying,
+// mixed alphabet & symbols. The factory applies the mode options.
+type SequencerFactory func(p *Parameters, opts Options) IKeySequencer
+
+/* ----------------------------------------------------------------
+ *				I n i t i a l i z e r
+ *-----------------------------------------------------------------*/
+
+func init() {
+	mustRegister(CaesarMode, func(p *Parameters, _ Options) IKeySequencer {
+		return cipher.NewCaesarSequencer(p)
+	})
+	mustRegister(DidimusMode, func(p *Parameters, opts Options) IKeySequencer {
+		p.SetAltKeyOffset(opts.Offset)
+		return cipher.NewDidimusSequencer(p)
+	})
+	mustRegister(FibonacciMode, func(p *Parameters, opts Options) IKeySequencer {
+		p.Fibonacci = opts.Fibonacci
+		return cipher.NewFibonacciSequencer(p)
+	})
+	mustRegister(PrimusMode, func(p *Parameters, opts Options) IKeySequencer {
+		p.Offset = opts.Offset
+		p.Primus = opts.Primes
+		return cipher.NewPrimusSequencer(p)
+	})
+	mustRegister(VigenereMode, func(p *Parameters, opts Options) IKeySequencer {
+		p.Keyword = opts.Keyword
+		return cipher.NewVigenereSequencer(p)
+	})
+	mustRegister(AutokeyMode, func(p *Parameters, opts Options) IKeySequencer {
+		p.Keyword = opts.Keyword
+		return cipher.NewAutokeySequencer(p, false)
+	})
+	mustRegister(AutokeyCipherMode, func(p *Parameters, opts Options) IKeySequencer {
+		p.Keyword = opts.Keyword
+		return cipher.NewAutokeySequencer(p, true)
+	})
+	mustRegister(AffineMode, func(p *Parameters, opts Options) IKeySequencer {
+		p.Offset = opts.Multiplier
+		return cipher.NewAffineSequencer(p)
+	})
+	mustRegister(AtbashMode, func(p *Parameters, _ Options) IKeySequencer {
+		return cipher.NewAtbashSequencer(p)
+	})
+	mustRegister(XorshiftMode, func(p *Parameters, opts Options) IKeySequencer {
+		p.Offset = opts.Offset
+		p.Keyword = opts.Keyword
+		return cipher.NewXorshiftSequencer(p)
+	})
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+// Registers a custom cipher mode. The name must not be empty nor be
+// that of an already registered mode.
+func Register(mode Mode, factory SequencerFactory) error {
+	if len(mode) == 0 || factory == nil {
+		return fmt.Errorf("cannot register cipher mode '%s' without name or factory", mode)
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, exists := registry.factories[mode]; exists {
+		return fmt.Errorf("cipher mode '%s' is already registered", mode)
+	}
+	registry.factories[mode] = factory
+	registry.modes = append(registry.modes, mode)
+
+	return nil
+}
+
+// the registered cipher modes, the built-in ones first
+func Modes() []Mode {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	modes := make([]Mode, len(registry.modes))
+	copy(modes, registry.modes)
+	return modes
+}
+
+// whether the cipher mode is registered
+func IsRegistered(mode Mode) bool {
+	_, err := lookup(mode)
+	return err == nil
+}
+
+func lookup(mode Mode) (SequencerFactory, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	if factory, ok := registry.factories[mode]; ok {
+		return factory, nil
+	}
+	return nil, fmt.Errorf("unregistered cipher mode: %s", mode)
+}
+
+func mustRegister(mode Mode, factory SequencerFactory) {
+	if err := Register(mode, factory); err != nil {
+		panic(err)
+	}
+}
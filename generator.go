@@ -392,6 +392,12 @@ func GenerateDualCaesarWheel(letters, symbols string, filename string, inner boo
 		dc.Stroke()
 	}
 
+	// A reversed inner disk runs counter-clockwise (Atbash/Beaufort disk)
+	if inner && opts.Reversed {
+		letters = ReverseString(letters)
+		symbols = ReverseString(symbols)
+	}
+
 	// III. Draw the N dividing lines and characters
 	letterLabel := []rune(letters) // each letter MAY be a multi-byte rune
 	symbolLabel := []rune(symbols)
@@ -572,6 +578,12 @@ func generateCaesarWheel(letters string, inner bool, opts CaesarWheelOptions) (*
 		dc.Stroke()
 	}
 
+	// A reversed inner disk runs counter-clockwise so that at shift
+	// zero it reflects the outer alphabet (Atbash/Beaufort disk).
+	if inner && opts.Reversed {
+		letters = ReverseString(letters)
+	}
+
 	// Draw the N dividing lines and characters
 	letterLabel := []rune(letters) // each letter MAY be a multi-byte rune
 	n := len(letterLabel)          // the length in Unicode chars rather than bytes
@@ -6,6 +6,7 @@ require (
 	fyne.io/fyne/v2 v2.7.2
 	github.com/fogleman/gg v1.3.0
 	golang.org/x/image v0.35.0
+	golang.org/x/text v0.33.0
 )
 
 require github.com/lordofscripts/goapp v1.2.0
@@ -42,6 +43,5 @@ require (
 	github.com/yuin/goldmark v1.7.16 // indirect
 	golang.org/x/net v0.49.0 // indirect
 	golang.org/x/sys v0.40.0 // indirect
-	golang.org/x/text v0.33.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
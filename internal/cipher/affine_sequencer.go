@@ -0,0 +1,184 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Key sequencer for the Affine cipher E(x) = a·x + b (mod N) where x
+ * is the position of the plain character in the alphabet and N is the
+ * alphabet length. The main key is b and the Offset is the multiplier
+ * a, which must be coprime with N so that the mapping can be reversed.
+ * Plain Caesar is the special case a=1. It works over any alphabet,
+ * including the multi-byte ones (Czech N=42, Russian N=33).
+ * Version: 1
+ * Class: Caesar (substitution cipher)
+ * Mode : Affine
+ * Type : Monoalphabetic cipher (1)
+ *-----------------------------------------------------------------*/
+package cipher
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lordofscripts/caesardisk"
+)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+/* ----------------------------------------------------------------
+ *				I n t e r f a c e s
+ *-----------------------------------------------------------------*/
+
+var _ ITabulaSequencer = (*AffineSequencer)(nil)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// Affine is a monoalphabetic sequencer, the key shift is always the
+// main key (b) but the tabula is scaled by the multiplier (a).
+type AffineSequencer struct {
+	CaesarSequencer
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// (Ctor) a new instance of the Affine sequencer. The par.KeyValue is
+// the additive key (b) and par.Offset the multiplier (a).
+func NewAffineSequencer(par *CaesarParameters) *AffineSequencer {
+	return &AffineSequencer{
+		CaesarSequencer: *NewCaesarSequencer(par),
+	}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+func (as *AffineSequencer) String() string {
+	return fmt.Sprintf("Affine(a=%d,b=%d)", as.params.Offset, as.params.KeyValue)
+}
+
+// Corrects the additive key like plain Caesar and the multiplier
+// to the nearest value that is coprime with the alphabet length.
+// The Beaufort directions are rejected.
+func (as *AffineSequencer) Validate() error {
+	if err := as.params.checkCipherAlpha(); err != nil {
+		return err
+	}
+	if err := as.params.Direction.checkStandard(); err != nil {
+		return err
+	}
+
+	warnB := as.CaesarSequencer.Validate()
+	mult, warnA := AffineCorrection(as.params.Offset, as.params.Alphabet)
+	if warnA != nil {
+		as.params.Offset = mult
+	}
+
+	as.isValid = true
+	return errors.Join(warnB, warnA)
+}
+
+// The valid range of Affine additive keys.
+func (as *AffineSequencer) KeyRange() (min, max int) {
+	return as.CaesarSequencer.KeyRange()
+}
+
+func (as *AffineSequencer) GetParams() *CaesarParameters {
+	return as.params
+}
+
+// Affine is stateless, nothing to rewind
+func (as *AffineSequencer) Reset() {
+}
+
+// an independent copy at the same position of the key sequence
+func (as *AffineSequencer) Clone() IKeySequencer {
+	clone := *as
+	clone.CaesarSequencer = as.CaesarSequencer.cloneBase()
+	return &clone
+}
+
+// Affine uses the same additive key throughout the message.
+func (as *AffineSequencer) NextKey() int {
+	return as.params.KeyValue
+}
+
+// The internal key schedule
+func (as *AffineSequencer) GetRawKeySchedule() []KeyScheduleItemInt {
+	return []KeyScheduleItemInt{
+		{KeyShift: as.params.KeyValue, Comment: fmt.Sprintf("a=%d", as.params.Offset)},
+	}
+}
+
+// implements ITabulaSequencer. The character at position X of the
+// tabula is the alphabet character at position a·X + b (mod N).
+// Only the standard Direction is valid for Affine.
+func (as *AffineSequencer) Tabula(alphabet string, keyShift int) string {
+	runic := []rune(alphabet)
+	N := len(runic)
+	tabula := make([]rune, N)
+	for x := range N {
+		tabula[x] = runic[(((as.params.Offset*x+keyShift)%N)+N)%N]
+	}
+	return string(tabula)
+}
+
+// Affine is a monoalphabetic substitution cipher
+func (as *AffineSequencer) IsPolyalphabetic() bool {
+	return false
+}
+
+// whether the Offset parameter is used in key sequencing.
+// Affine uses the Offset as the multiplier.
+func (as *AffineSequencer) IsOffsetRequired() bool {
+	return true
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+// A corrector for the Affine multiplier. It is first normalized to
+// 0..N-1 and, if it is not coprime with the alphabet length N, the
+// nearest coprime value is proposed (the smaller one on a tie).
+//
+// NOTE:
+//
+//	The return warn value is a WARNING not an error, it simply
+//	indicates whether corrections/normalizations were made.
+func AffineCorrection(multiplier int, alpha *caesardisk.AlphabetModel) (mult int, warn error) {
+	N := alpha.Length()
+	norm := ((multiplier % N) + N) % N
+
+	mult = norm
+	for delta := 0; delta < N; delta++ {
+		if below := norm - delta; below > 0 && gcd(below, N) == 1 {
+			mult = below
+			break
+		}
+		if above := norm + delta; above < N && gcd(above, N) == 1 {
+			mult = above
+			break
+		}
+	}
+
+	if mult != multiplier {
+		warn = fmt.Errorf("affine multiplier corrected %02d->%02d [N:%d Coprime:%t]", multiplier, mult, N, gcd(norm, N) == 1)
+	}
+
+	return
+}
+
+// greatest common divisor
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,86 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Key sequencer for Atbash mode. Instead of rotating the alphabet it
+ * reflects it, the first letter maps to the last and so on. On a disk
+ * it is an inner ring that runs counter-clockwise. The main key turns
+ * that reversed ring, with key zero being the classic Atbash. Every
+ * key gives a reciprocal cipher: C = N-1 - P - K (mod N).
+ * Version: 1
+ * Class: Caesar (substitution cipher)
+ * Mode : Atbash
+ * Type : Monoalphabetic cipher (1)
+ *-----------------------------------------------------------------*/
+package cipher
+
+import (
+	"fmt"
+
+	"github.com/lordofscripts/caesardisk"
+)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+/* ----------------------------------------------------------------
+ *				I n t e r f a c e s
+ *-----------------------------------------------------------------*/
+
+var _ ITabulaSequencer = (*AtbashSequencer)(nil)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// Atbash is a monoalphabetic sequencer whose tabula is the reversed
+// (counter-clockwise) alphabet turned by the main key.
+type AtbashSequencer struct {
+	CaesarSequencer
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// (Ctor) a new instance of the Atbash sequencer. The par.KeyValue
+// turns the reversed ring, zero is the classic Atbash.
+func NewAtbashSequencer(par *CaesarParameters) *AtbashSequencer {
+	return &AtbashSequencer{
+		CaesarSequencer: *NewCaesarSequencer(par),
+	}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+func (as *AtbashSequencer) String() string {
+	char, _ := as.params.Alphabet.Character(as.params.KeyValue)
+
+	return fmt.Sprintf("Atbash(%c|%d)", char, as.params.KeyValue)
+}
+
+// Corrects the key like plain Caesar, the Beaufort directions are
+// rejected.
+func (as *AtbashSequencer) Validate() error {
+	if err := as.params.Direction.checkStandard(); err != nil {
+		return err
+	}
+	return as.CaesarSequencer.Validate()
+}
+
+// an independent copy, Atbash is stateless
+func (as *AtbashSequencer) Clone() IKeySequencer {
+	return &AtbashSequencer{
+		CaesarSequencer: as.CaesarSequencer.cloneBase(),
+	}
+}
+
+// implements ITabulaSequencer. The tabula is the reversed alphabet
+// rotated left by the key shift. Only the standard Direction is valid.
+func (as *AtbashSequencer) Tabula(alphabet string, keyShift int) string {
+	return RotateStringLeft(caesardisk.ReverseString(alphabet), keyShift)
+}
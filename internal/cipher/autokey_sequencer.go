@@ -0,0 +1,195 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Caesar key sequencer for Autokey mode. The key schedule starts with
+ * a primer, the letters of the keyword or the main key when there is
+ * no keyword. Once the primer is used up, it continues with the letters
+ * of the message itself: the plain text (plaintext feedback) or the
+ * cipher text (ciphertext feedback). The letters are fed back by the
+ * transcoder via IFeedbackSequencer, together with the symbols of a
+ * dual disk. Characters that pass through take no key at all.
+ * Version: 1
+ * Class: Caesar (substitution cipher)
+ * Mode : Autokey
+ * Type : Polyalphabetic cipher (message length)
+ *-----------------------------------------------------------------*/
+package cipher
+
+import (
+	"fmt"
+)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+/* ----------------------------------------------------------------
+ *				I n t e r f a c e s
+ *-----------------------------------------------------------------*/
+
+var _ IFeedbackSequencer = (*AutokeySequencer)(nil)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// Autokey uses a poly-alphabetic sequencer whose keys are the primer
+// followed by the message letters (plain or ciphered) fed back by
+// the transcoder.
+type AutokeySequencer struct {
+	CaesarSequencer
+	// the key shifts of the primer (keyword or main key)
+	primer []int
+	// pending key shifts, the primer and the letters fed back so far
+	pending []int
+	// whether the ciphertext rather than plaintext is fed back
+	withCiphertext bool
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// (Ctor) a new instance of the Autokey sequencer for the Caesar encoder.
+// The primer is par.Keyword mapped through par.Alphabet or, if it has
+// no letters of the alphabet, the main key par.KeyValue.
+func NewAutokeySequencer(par *CaesarParameters, withCiphertext bool) *AutokeySequencer {
+	primer := KeywordShifts(par.Keyword, par.Alphabet)
+	if len(primer) == 0 {
+		primer = []int{par.KeyValue}
+	}
+
+	return &AutokeySequencer{
+		CaesarSequencer: *NewCaesarSequencer(par),
+		primer:          primer,
+		pending:         append([]int{}, primer...),
+		withCiphertext:  withCiphertext,
+	}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+func (as *AutokeySequencer) String() string {
+	feed := "P"
+	if as.withCiphertext {
+		feed = "C"
+	}
+
+	return fmt.Sprintf("Autokey(%s,K(%d),%s)", as.params.Keyword, len(as.primer), feed)
+}
+
+// Without a keyword the primer is the main key, which is then corrected
+// like for plain Caesar.
+func (as *AutokeySequencer) Validate() error {
+	if err := as.params.checkCipherAlpha(); err != nil {
+		return err
+	}
+	if len(KeywordShifts(as.params.Keyword, as.params.Alphabet)) != 0 {
+		as.isValid = true
+		return nil
+	}
+
+	warn := as.CaesarSequencer.Validate()
+	as.primer = []int{as.params.KeyValue}
+	as.pending = append([]int{}, as.primer...)
+	return warn
+}
+
+// The valid range of Autokey keys.
+func (as *AutokeySequencer) KeyRange() (min, max int) {
+	return as.CaesarSequencer.KeyRange()
+}
+
+func (as *AutokeySequencer) GetParams() *CaesarParameters {
+	return as.params
+}
+
+// rewinds the key stream to the primer, forgetting the feedback
+func (as *AutokeySequencer) Reset() {
+	as.pending = append([]int{}, as.primer...)
+}
+
+// an independent copy at the same position of the key sequence
+func (as *AutokeySequencer) Clone() IKeySequencer {
+	clone := *as
+	clone.CaesarSequencer = as.CaesarSequencer.cloneBase()
+	clone.pending = append([]int{}, as.pending...)
+	return &clone
+}
+
+// Autokey keys past the primer are the message itself, which is not
+// known when seeking, therefore only the primer can be sought.
+func (as *AutokeySequencer) SeekTo(n int) error {
+	if err := checkSeekPosition(n); err != nil {
+		return err
+	}
+	if n >= len(as.primer) {
+		return fmt.Errorf("Autokey cannot seek past the primer (%d): %d", len(as.primer), n)
+	}
+
+	as.pending = append([]int{}, as.primer[n:]...)
+	return nil
+}
+
+// The next key is the oldest pending key. If the caller did not feed
+// back the previous characters, the primer is reused.
+func (as *AutokeySequencer) NextKey() int {
+	if len(as.pending) == 0 {
+		as.pending = append(as.pending, as.primer...)
+	}
+
+	keyShift := as.pending[0]
+	as.pending = as.pending[1:]
+
+	return keyShift
+}
+
+// implements IFeedbackSequencer. The plain or ciphered letter, or the
+// symbol of a dual disk, is appended to the key stream.
+func (as *AutokeySequencer) Feedback(plain, ciphered rune) {
+	char := plain
+	if as.withCiphertext {
+		char = ciphered
+	}
+
+	at := as.params.Alphabet.Find(char)
+	if at == -1 && as.params.Symbols != nil {
+		at = as.params.Symbols.FindExact(char)
+	}
+	if at != -1 {
+		as.pending = append(as.pending, at)
+	}
+}
+
+// The internal key schedule. Only the primer is known in advance,
+// the rest of the schedule is the message itself.
+func (as *AutokeySequencer) GetRawKeySchedule() []KeyScheduleItemInt {
+	qty := len(as.primer)
+	schedule := make([]KeyScheduleItemInt, qty)
+	for i := range qty {
+		schedule[i] = KeyScheduleItemInt{
+			KeyShift: as.primer[i],
+			Comment:  fmt.Sprintf("Primer #%d", i)}
+	}
+	return schedule
+}
+
+// Autokey is a polyalphabetic substitution cipher
+func (as *AutokeySequencer) IsPolyalphabetic() bool {
+	return true
+}
+
+// whether the Offset parameter is used in key sequencing.
+// Autokey uses a keyword (primer) instead.
+func (as *AutokeySequencer) IsOffsetRequired() bool {
+	return false
+}
+
+// whether the cipher text rather than the plain text is fed back
+func (as *AutokeySequencer) IsCiphertextFeedback() bool {
+	return as.withCiphertext
+}
@@ -4,16 +4,18 @@
  *						goCaesarDisk GUI
  * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
  * Implements basic Caesar cipher encoding/decoding using Unicode
- * (foreign) alphabets (not just ASCII).
+ * (foreign) alphabets (not just ASCII). The input is brought to the
+ * Unicode normalization form NFC of the alphabets, therefore decomposed
+ * and precomposed text encrypt identically. The output is always NFC.
  *-----------------------------------------------------------------*/
 package cipher
 
 import (
-	"slices"
 	"strings"
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/lordofscripts/caesardisk"
 	"github.com/lordofscripts/caesardisk/internal/hash"
 )
 
@@ -41,12 +43,55 @@ var _ ITranscoder = (*Caesar)(nil)
 
 type Caesar struct {
 	sequencer IKeySequencer
+	// when set, a message continues the key sequence of the previous
+	// one (multi-part messages) instead of starting afresh.
+	continueStream bool
 }
 
 /* ----------------------------------------------------------------
  *				P r i v a t e	T y p e s
  *-----------------------------------------------------------------*/
 
+// transcodes one rune at a time, the sequencer state is kept between
+// runes. Used by Encode/Decode and by the streaming reader/writer.
+type runeTranscoder struct {
+	c         *Caesar
+	direction Direction
+	keying    KeyingPolicy
+	alphabet  *caesardisk.AlphabetModel // the plain-text alphabet
+	letters   *transcodingRing          // the ring of letters
+	// sequencers with their own tabula (ITabulaSequencer) cannot use
+	// the index arithmetic of the Direction
+	tabulator    ITabulaSequencer
+	hasTabulator bool
+	// autokey sequencers need to observe the transcoded characters
+	feedback    IFeedbackSequencer
+	hasFeedback bool
+	// the (optional) ring of symbols of a dual disk
+	symbols *transcodingRing
+}
+
+// a pair of plain & cipher rings of a disk. Their positions are mapped
+// with the index arithmetic of the Direction, the rune->position maps
+// of the alphabets spare building a tabula for every key shift. The
+// paired ring of symbols (digits & punctuation) of a dual disk turns
+// together with the ring of letters, therefore a symbol is transcoded
+// with the key of its position in the key schedule.
+type transcodingRing struct {
+	plain       *caesardisk.AlphabetModel
+	cipher      *caesardisk.AlphabetModel // the (possibly mixed) inner ring
+	plainRunes  []rune
+	cipherRunes []rune
+	// the tabulas of an ITabulaSequencer, computed once per key shift
+	tabulas map[int]*cachedTabula
+}
+
+// a ciphered tabula with its rune->position map for decoding
+type cachedTabula struct {
+	runes []rune
+	index map[rune]int
+}
+
 /* ----------------------------------------------------------------
  *				C o n s t r u c t o r s
  *-----------------------------------------------------------------*/
@@ -106,6 +151,57 @@ func NewPrimusCipher(config *CaesarParameters) *Caesar {
 	}
 }
 
+// (ctor) Vigenère is a polyalphabetic substitution cipher based
+// on Caesar. Instead of a fixed table of offsets, the key shift of
+// every encodeable character is given by the next letter of the
+// keyword (config.Keyword) in the current alphabet.
+// Note: the config.KeyValue and config.Offset are not used.
+func NewVigenereCipher(config *CaesarParameters) *Caesar {
+	return &Caesar{
+		sequencer: NewVigenereSequencer(config),
+	}
+}
+
+// (ctor) Autokey is a polyalphabetic substitution cipher based on
+// Caesar. The key schedule starts with a primer (config.Keyword, or
+// the main key if there is no keyword) and continues with the letters
+// of the message itself, either plain text or cipher text.
+// Note: the config.Offset is not used.
+func NewAutokeyCipher(config *CaesarParameters, withCiphertext bool) *Caesar {
+	return &Caesar{
+		sequencer: NewAutokeySequencer(config, withCiphertext),
+	}
+}
+
+// (ctor) Affine is a monoalphabetic substitution cipher that maps
+// the character at position X to position a·X + b (mod N). The
+// config.KeyValue is b and config.Offset is the multiplier a, which
+// must be coprime with the alphabet length N.
+func NewAffineCipher(config *CaesarParameters) *Caesar {
+	return &Caesar{
+		sequencer: NewAffineSequencer(config),
+	}
+}
+
+// (ctor) Atbash is a monoalphabetic substitution cipher that
+// reflects the alphabet rather than rotating it. The config.KeyValue
+// turns the reflected ring, zero being the classic Atbash.
+// Note: the config.Offset is not used.
+func NewAtbashCipher(config *CaesarParameters) *Caesar {
+	return &Caesar{
+		sequencer: NewAtbashSequencer(config),
+	}
+}
+
+// (ctor) Xorshift is a polyalphabetic substitution cipher whose key
+// stream comes from a xorshift64* generator seeded with the main key,
+// the Offset and the Keyword (passphrase).
+func NewXorshiftCipher(config *CaesarParameters) *Caesar {
+	return &Caesar{
+		sequencer: NewXorshiftSequencer(config),
+	}
+}
+
 /* ----------------------------------------------------------------
  *				P u b l i c		M e t h o d s
  *-----------------------------------------------------------------*/
@@ -115,6 +211,21 @@ func (c *Caesar) String() string {
 	return c.sequencer.String()
 }
 
+// By default every Encode/Decode starts from the first key of the
+// sequence, so the same message always gives the same result. With
+// continue set, each call continues the key sequence where the last
+// one left off, as if all parts were a single message.
+func (c *Caesar) ContinueStream(enabled bool) *Caesar {
+	c.continueStream = enabled
+	return c
+}
+
+// rewinds the key sequence, the next message starts afresh even
+// when continuing the stream.
+func (c *Caesar) Reset() {
+	c.sequencer.Reset()
+}
+
 // encode a message and package it in a "standard" form. The
 // standard PDU format is {TIMESTAMP}{CHECKSUM}{PAYLOAD} where
 // Payload is the encrypted message string, Checksum is the checksum
@@ -138,126 +249,278 @@ func (c *Caesar) DecodeMessage(cipheredMessage string) (string, error) {
 }
 
 // implements ITranscoder for encoding/encrypting a message using
-// the selected Caesar mode/variant (Caesar, Didimus, Fibonacci, Primus)
+// the selected Caesar mode/variant (Caesar, Didimus, Fibonacci, Primus, Vigenère, Autokey, Affine, Atbash, Xorshift)
 func (c *Caesar) Encode(plain string) string {
 	var result strings.Builder
-	var alphabet string = c.sequencer.GetParams().Alphabet.String()
-
-	var tabulaIn []rune  // the plain-text alphabet tabula
-	var tabulaRaw string // the raw ciphered alphabet string
-	var tabulaOut []rune // the ciphered alphabet tabula
-	if !c.sequencer.IsPolyalphabetic() {
-		withKey := c.sequencer.GetParams().KeyValue
-		tabulaRaw = RotateStringLeft(alphabet, withKey)
-		tabulaOut = []rune(tabulaRaw)
-	}
-	// during encryption we map from tabulaIn to tabulaOut
-	tabulaIn = []rune(alphabet)
+	transcoder := c.newRuneTranscoder()
+	plain = transcoder.alphabet.Normalize(plain)
 
 	// · iterate through each of the plain-text Unicode characters in the input string
 	for _, plainRune := range []rune(plain) {
-		// · Letter-case preservation
-		//   the reference alphabet is Uppercase, input may be lowercase
-		isLower := unicode.IsLower(plainRune)
-		if isLower {
-			plainRune = unicode.ToUpper(plainRune)
-		}
+		result.WriteRune(transcoder.encode(plainRune))
+	}
 
-		if at := slices.Index(tabulaIn, plainRune); at != -1 {
-			// · The Unicode point CAN be encoded (present in alphabet)
-			//	 select the appropriate key & tabula for polialphabetic ciphers
-			withKey := c.sequencer.NextKey()
-			if c.sequencer.IsPolyalphabetic() {
-				tabulaRaw = RotateStringLeft(alphabet, withKey)
-				tabulaOut = []rune(tabulaRaw)
-			}
+	return result.String()
+}
 
-			// · map from tabulaIn (withKey) to tabulaOut content
-			ciphered := tabulaOut[at]
-			// · preserve case on output
-			if isLower {
-				ciphered = unicode.ToLower(ciphered)
-			}
+// implements ITranscoder for decoding/decrypting a message using
+// the selected Caesar mode/variant (Caesar, Didimus, Fibonacci, Primus, Vigenère, Autokey, Affine, Atbash, Xorshift)
+func (c *Caesar) Decode(ciphered string) string {
+	var result strings.Builder
+	transcoder := c.newRuneTranscoder()
+	ciphered = transcoder.alphabet.Normalize(ciphered)
 
-			// · write the encrypted Unicode character
-			result.WriteRune(ciphered)
-		} else {
-			// · The Unicode point CANNOT be encoded (not present in alphabet)
-			//	 pass it through as-is.
-			result.WriteRune(plainRune)
-		}
+	for _, cipherRune := range []rune(ciphered) {
+		result.WriteRune(transcoder.decode(cipherRune))
 	}
 
 	return result.String()
 }
 
-// implements ITranscoder for decoding/decrypting a message using
-// the selected Caesar mode/variant (Caesar, Didimus, Fibonacci, Primus)
-func (c *Caesar) Decode(ciphered string) string {
+// encodes a fragment of a message that starts at the given position,
+// the number of encodeable characters that precede it in the message.
+func (c *Caesar) EncodeAt(plain string, position int) (string, error) {
+	if err := c.sequencer.SeekTo(position); err != nil {
+		return "", err
+	}
+
 	var result strings.Builder
-	var alphabet string = c.sequencer.GetParams().Alphabet.String()
-	var tabulaIn []rune  // the plain-text alphabet tabula
-	var tabulaRaw string // the raw ciphered alphabet string
-	var tabulaOut []rune // the ciphered alphabet tabula
+	transcoder := c.runeTranscoderHere()
+	plain = transcoder.alphabet.Normalize(plain)
+	for _, plainRune := range []rune(plain) {
+		result.WriteRune(transcoder.encode(plainRune))
+	}
+
+	return result.String(), nil
+}
 
-	// · slight optimization for monoalphabetic modes
-	if !c.sequencer.IsPolyalphabetic() {
-		withKey := c.sequencer.GetParams().KeyValue
-		tabulaRaw = RotateStringLeft(alphabet, withKey)
-		tabulaOut = []rune(tabulaRaw)
+// decodes a fragment of a message (e.g. a single line) without
+// replaying everything before it. The position is the number of
+// encodeable characters that precede the fragment in the message,
+// see EncodeablePosition(), or simply its rune offset when every
+// character is keyed (CountAllKeying).
+func (c *Caesar) DecodeAt(ciphered string, position int) (string, error) {
+	if err := c.sequencer.SeekTo(position); err != nil {
+		return "", err
 	}
-	// during decryption we map from tabulaOut to tabulaIn
-	tabulaIn = []rune(alphabet)
 
+	var result strings.Builder
+	transcoder := c.runeTranscoderHere()
+	ciphered = transcoder.alphabet.Normalize(ciphered)
 	for _, cipherRune := range []rune(ciphered) {
-		// · Letter-case preservation
-		// the reference alphabet is Uppercase, input may be lowercase
-		isLower := unicode.IsLower(cipherRune)
-		if isLower {
-			cipherRune = unicode.ToUpper(cipherRune)
-		}
+		result.WriteRune(transcoder.decode(cipherRune))
+	}
 
-		// we cannot use at just yet because for polyalphabetic
-		// we have not yet (re)constructed tabulaOut
-		if at := slices.Index(tabulaIn, cipherRune); at != -1 {
-			// · The Unicode point CAN be decoded (present in alphabet)
-			//	 select the appropriate key & tabula for polialphabetic ciphers
-			withKey := c.sequencer.NextKey()
-			if c.sequencer.IsPolyalphabetic() {
-				tabulaRaw = RotateStringLeft(alphabet, withKey)
-				tabulaOut = []rune(tabulaRaw)
-			}
+	return result.String(), nil
+}
 
-			// · Now that we have the ciphered tabula, we can determine index.
-			//   This works because both tabulaIn & tabulaOut contain the same
-			//	 character set, i.e. not transliteration of text to symbols.
-			//	 Therefore, the following value will always be >= 0
-			at = slices.Index(tabulaOut, cipherRune)
-			plain := tabulaIn[at]
-			// · preserve the input letter-case
-			if isLower {
-				plain = unicode.ToLower(plain)
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// a rune transcoder for a new message, it starts with a fresh key
+// sequence unless continuing the stream.
+func (c *Caesar) newRuneTranscoder() *runeTranscoder {
+	if !c.continueStream {
+		c.sequencer.Reset()
+	}
+
+	return c.runeTranscoderHere()
+}
+
+// a rune transcoder that starts at the current sequencer position
+func (c *Caesar) runeTranscoderHere() *runeTranscoder {
+	params := c.sequencer.GetParams()
+	rt := &runeTranscoder{
+		c:         c,
+		direction: params.Direction,
+		keying:    params.Keying,
+		alphabet:  params.Alphabet,
+		letters:   newTranscodingRing(params.Alphabet, params.GetCipherAlpha()),
+	}
+
+	rt.tabulator, rt.hasTabulator = c.sequencer.(ITabulaSequencer)
+	rt.feedback, rt.hasFeedback = c.sequencer.(IFeedbackSequencer)
+	// · the symbols ring must be as long as the letters ring
+	if params.Symbols != nil && params.Symbols.Length() == params.Alphabet.Length() {
+		rt.symbols = newTranscodingRing(params.Symbols, params.Symbols)
+	}
+
+	return rt
+}
+
+// encodes a single plain rune
+func (rt *runeTranscoder) encode(plainRune rune) rune {
+	// · Letter-case preservation
+	//   the reference alphabet is Uppercase, input may be lowercase
+	isLower := unicode.IsLower(plainRune)
+	if isLower {
+		plainRune = caesardisk.UpperCase(plainRune)
+	}
+
+	at := rt.alphabet.FindExact(plainRune)
+	if at == -1 && rt.symbols != nil {
+		// · A symbol of the dual disk is encoded with the paired ring
+		if at = rt.symbols.plain.FindExact(plainRune); at != -1 {
+			ciphered := rt.encipher(rt.symbols, at, rt.c.sequencer.NextKey())
+			if rt.hasFeedback {
+				rt.feedback.Feedback(plainRune, ciphered)
 			}
+			return ciphered
+		}
+	}
+	if at == -1 {
+		// · The Unicode point CANNOT be encoded (not present in alphabet)
+		//	 pass it through as-is, it may still take its key position.
+		if rt.keying.KeysForeign() && !rt.hasFeedback {
+			rt.c.sequencer.NextKey()
+		}
+		return plainRune
+	}
+
+	// · The Unicode point CAN be encoded (present in alphabet)
+	ciphered := rt.encipher(rt.letters, at, rt.c.sequencer.NextKey())
+	if rt.hasFeedback {
+		rt.feedback.Feedback(plainRune, ciphered)
+	}
+	// · preserve case on output
+	if isLower {
+		ciphered = unicode.ToLower(ciphered)
+	}
+
+	return ciphered
+}
 
-			// · Write to output decoded string
-			result.WriteRune(plain)
-		} else {
-			// · If not present, pass as-is to the output string
-			result.WriteRune(cipherRune)
+// decodes a single ciphered rune
+func (rt *runeTranscoder) decode(cipherRune rune) rune {
+	// · Letter-case preservation
+	// the reference alphabet is Uppercase, input may be lowercase
+	isLower := unicode.IsLower(cipherRune)
+	if isLower {
+		cipherRune = caesardisk.UpperCase(cipherRune)
+	}
+
+	if rt.alphabet.FindExact(cipherRune) == -1 && rt.symbols != nil {
+		// · A symbol of the dual disk is decoded with the paired ring
+		if rt.symbols.plain.FindExact(cipherRune) != -1 {
+			plain := rt.decipher(rt.symbols, cipherRune, rt.c.sequencer.NextKey())
+			if rt.hasFeedback {
+				rt.feedback.Feedback(plain, cipherRune)
+			}
+			return plain
+		}
+	}
+	if rt.alphabet.FindExact(cipherRune) == -1 {
+		// · If not present, pass as-is to the output
+		if rt.keying.KeysForeign() && !rt.hasFeedback {
+			rt.c.sequencer.NextKey()
 		}
+		return cipherRune
 	}
 
-	return result.String()
+	// · The Unicode point CAN be decoded (present in alphabet). Both
+	//	 rings contain the same character set, i.e. no transliteration
+	//	 of text to symbols, whatever the Direction.
+	plain := rt.decipher(rt.letters, cipherRune, rt.c.sequencer.NextKey())
+	if rt.hasFeedback {
+		rt.feedback.Feedback(plain, cipherRune)
+	}
+	// · preserve the input letter-case
+	if isLower {
+		plain = unicode.ToLower(plain)
+	}
+
+	return plain
 }
 
-/* ----------------------------------------------------------------
- *				P r i v a t e	M e t h o d s
- *-----------------------------------------------------------------*/
+// the encryption of the character at the plain position with the key
+// shift, see CipherTabula().
+func (rt *runeTranscoder) encipher(ring *transcodingRing, at, withKey int) rune {
+	if rt.hasTabulator {
+		return ring.tabulaFor(rt.tabulator, withKey).runes[at]
+	}
+	return ring.cipherRunes[rt.direction.cipherPosition(at, withKey, len(ring.cipherRunes))]
+}
+
+// the decryption of a character of the cipher ring with the key shift
+func (rt *runeTranscoder) decipher(ring *transcodingRing, cipherRune rune, withKey int) rune {
+	if rt.hasTabulator {
+		return ring.plainRunes[ring.tabulaFor(rt.tabulator, withKey).index[cipherRune]]
+	}
+	at := ring.cipher.FindExact(cipherRune)
+	return ring.plainRunes[rt.direction.plainPosition(at, withKey, len(ring.plainRunes))]
+}
+
+// the tabula of the sequencer for the key shift, computed on first use.
+// For a given message the tabula depends only on the key shift.
+func (tr *transcodingRing) tabulaFor(ts ITabulaSequencer, withKey int) *cachedTabula {
+	if cached, ok := tr.tabulas[withKey]; ok {
+		return cached
+	}
+
+	cached := newCachedTabula(ts.Tabula(tr.cipher.String(), withKey))
+	tr.tabulas[withKey] = cached
+
+	return cached
+}
 
 /* ----------------------------------------------------------------
  *					F u n c t i o n s
  *-----------------------------------------------------------------*/
 
+// the number of encodeable characters (those in the alphabet) among
+// the first runeOffset runes of the text, i.e. the key sequence
+// position of a fragment that starts at runeOffset. The text may be
+// in any normalization form, the runeOffset refers to the text as is.
+// The symbols of a paired (dual disk) alphabet are encodeable too.
+func EncodeablePosition(text string, runeOffset int, alpha *caesardisk.AlphabetModel, paired ...*caesardisk.AlphabetModel) int {
+	runic := []rune(text)
+	if runeOffset < len(runic) {
+		runic = runic[:runeOffset]
+	}
+
+	position := 0
+	for _, char := range alpha.Normalize(string(runic)) {
+		char = caesardisk.UpperCase(char)
+		if alpha.FindExact(char) != -1 {
+			position++
+			continue
+		}
+		for _, symbols := range paired {
+			if symbols != nil && symbols.FindExact(char) != -1 {
+				position++
+				break
+			}
+		}
+	}
+	return position
+}
+
+// a plain & cipher ring pair, the alphabets must have the same length
+func newTranscodingRing(plain, cipher *caesardisk.AlphabetModel) *transcodingRing {
+	return &transcodingRing{
+		plain:       plain,
+		cipher:      cipher,
+		plainRunes:  []rune(plain.String()),
+		cipherRunes: []rune(cipher.String()),
+		tabulas:     make(map[int]*cachedTabula),
+	}
+}
+
+// a ciphered tabula with its rune->position map
+func newCachedTabula(tabula string) *cachedTabula {
+	runes := []rune(tabula)
+	cached := &cachedTabula{
+		runes: runes,
+		index: make(map[rune]int, len(runes)),
+	}
+	for at, char := range runes {
+		cached.index[char] = at
+	}
+
+	return cached
+}
+
 func RotateStringLeft(s string, shift int) string {
 	complementShift := utf8.RuneCountInString(s) - shift
 	return RotateStringRight(s, complementShift)
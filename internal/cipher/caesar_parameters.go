@@ -52,9 +52,26 @@ var _ IParamProvider = (*CaesarParameters)(nil)
 
 type CaesarParameters struct {
 	Alphabet *caesardisk.AlphabetModel
-	KeyValue int
-	Offset   int // not used for plain Caesar, just Didimus & Fibonacci
-	altKey   int // derived from key+offset not used in plain Caesar
+	// The (optional) mixed alphabet of the inner/cipher ring. It must
+	// be a permutation of Alphabet. When nil Alphabet is used.
+	CipherAlphabet *caesardisk.AlphabetModel
+	// The (optional) paired alphabet of symbols of a dual disk, e.g.
+	// caesardisk.Alpha_PU_DUAL_EN. Its symbols are transcoded with the
+	// same key schedule. It must be as long as Alphabet, else ignored.
+	Symbols *caesardisk.AlphabetModel
+
+	KeyValue  int
+	Offset    int       // not used for plain Caesar, just Didimus & Fibonacci
+	Keyword   string    // Vigenère, Autokey (primer) & Xorshift (passphrase)
+	Direction Direction // Standard, Beaufort or Variant Beaufort (all modes)
+	// Which characters advance the key: only alphabet letters or all.
+	Keying KeyingPolicy
+	altKey int // derived from key+offset not used in plain Caesar
+
+	// Fibonacci series (terms & seeds). When nil the default is used.
+	Fibonacci *FibonacciSeries
+	// Primus window of primes. When nil the default is used.
+	Primus *PrimeWindow
 }
 
 /* ----------------------------------------------------------------
@@ -65,10 +82,12 @@ type CaesarParameters struct {
 // chosen alphabet.
 func NewCaesarParameters(alphabet *caesardisk.AlphabetModel) *CaesarParameters {
 	return &CaesarParameters{
-		Alphabet: alphabet,
-		KeyValue: 0,
-		Offset:   -1,
-		altKey:   0,
+		Alphabet:  alphabet,
+		KeyValue:  0,
+		Offset:    -1,
+		Direction: StandardDirection,
+		Keying:    SkipForeignKeying,
+		altKey:    0,
 	}
 }
 
@@ -91,6 +110,15 @@ func (c *CaesarParameters) GetAlpha() *caesardisk.AlphabetModel {
 	return c.Alphabet
 }
 
+// Get the alphabet of the cipher (inner) ring, which is either the
+// mixed CipherAlphabet or the plain Alphabet.
+func (c *CaesarParameters) GetCipherAlpha() *caesardisk.AlphabetModel {
+	if c.CipherAlphabet != nil {
+		return c.CipherAlphabet
+	}
+	return c.Alphabet
+}
+
 func (c *CaesarParameters) GetKey() (rune, int) {
 	char, _ := c.Alphabet.Character(c.KeyValue)
 	return char, c.KeyValue
@@ -165,6 +193,15 @@ func (c *CaesarParameters) adjustOffset(offset int) int {
 	return OffsetAdjuster(c.Alphabet.Length(), c.KeyValue, offset)
 }
 
+// the (optional) mixed cipher alphabet must be a permutation of the
+// alphabet, else some letters could not be transcoded at all.
+func (c *CaesarParameters) checkCipherAlpha() error {
+	if c.CipherAlphabet != nil && !c.CipherAlphabet.IsPermutationOf(c.Alphabet) {
+		return caesardisk.ErrNotPermutation
+	}
+	return nil
+}
+
 /* ----------------------------------------------------------------
  *					F u n c t i o n s
  *-----------------------------------------------------------------*/
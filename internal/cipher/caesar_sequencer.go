@@ -78,6 +78,10 @@ func (cs *CaesarSequencer) String() string {
 // The return value is not an error but a warning, execution can
 // continue with adjusted values
 func (cs *CaesarSequencer) Validate() error {
+	if err := cs.params.checkCipherAlpha(); err != nil {
+		return err
+	}
+
 	if useCORRECTORS {
 		shf, warn := CaesarCorrection(cs.params.KeyValue, cs.params.Alphabet)
 		if warn != nil {
@@ -112,6 +116,20 @@ func (cs *CaesarSequencer) GetParams() *CaesarParameters {
 	return cs.params
 }
 
+// Caesar is stateless, nothing to rewind
+func (cs *CaesarSequencer) Reset() {}
+
+// an independent copy, Caesar is stateless
+func (cs *CaesarSequencer) Clone() IKeySequencer {
+	clone := cs.cloneBase()
+	return &clone
+}
+
+// Caesar uses the same key throughout, only validates the position
+func (cs *CaesarSequencer) SeekTo(n int) error {
+	return checkSeekPosition(n)
+}
+
 // Get the next key to use, should only be called if a message's
 // character is not skipped. Characters are skipped if they are not
 // part of the encoding alphabet, and thus do not participate in the
@@ -138,6 +156,20 @@ func (cs *CaesarSequencer) IsOffsetRequired() bool {
 	return false
 }
 
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// a copy of the base sequencer with its own copy of the parameters,
+// so that corrections made by Validate() do not leak between clones.
+func (cs *CaesarSequencer) cloneBase() CaesarSequencer {
+	params := *cs.params
+	return CaesarSequencer{
+		params:  &params,
+		isValid: cs.isValid,
+	}
+}
+
 /* ----------------------------------------------------------------
  *					F u n c t i o n s
  *-----------------------------------------------------------------*/
@@ -168,3 +200,11 @@ func CaesarCorrection(keyShift int, alpha *caesardisk.AlphabetModel) (main int,
 
 	return
 }
+
+// a seek position is the zero-based index of an encodeable character
+func checkSeekPosition(n int) error {
+	if n < 0 {
+		return fmt.Errorf("cannot seek to a negative position: %d", n)
+	}
+	return nil
+}
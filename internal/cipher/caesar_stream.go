@@ -0,0 +1,216 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Streaming Caesar-class transcoders. Large inputs are processed rune
+ * by rune without loading them entirely in memory. A multi-byte UTF-8
+ * character split across two buffers is held back until complete, and
+ * the key sequencer state continues across buffers, so the output is
+ * identical to Encode()/Decode() over the whole text, including the
+ * NFC normalization of the input. Invalid UTF-8 bytes are passed
+ * through untouched.
+ *-----------------------------------------------------------------*/
+package cipher
+
+import (
+	"bytes"
+	"io"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+// size of the chunks read from the source of a CipherReader
+const streamChunkSize int = 4096
+
+/* ----------------------------------------------------------------
+ *				I n t e r f a c e s
+ *-----------------------------------------------------------------*/
+
+var _ io.WriteCloser = (*CipherWriter)(nil)
+var _ io.Reader = (*CipherReader)(nil)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// An io.WriteCloser that encrypts (or decrypts) everything written to
+// it and writes the result to the underlying writer. Close() must be
+// called to flush an incomplete trailing UTF-8 sequence, it does NOT
+// close the underlying writer.
+type CipherWriter struct {
+	nfc  io.WriteCloser // normalizes the input before transcoding
+	sink *transcodingWriter
+}
+
+// An io.Reader that encrypts (or decrypts) what it reads from the
+// underlying reader.
+type CipherReader struct {
+	r       io.Reader
+	stream  runeStream
+	pending []byte       // bytes of an incomplete UTF-8 sequence
+	out     bytes.Buffer // transcoded but not yet read
+	err     error        // sticky error of the underlying reader
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	T y p e s
+ *-----------------------------------------------------------------*/
+
+// transcodes the (normalized) input into the underlying writer
+type transcodingWriter struct {
+	w       io.Writer
+	stream  runeStream
+	pending []byte // bytes of an incomplete UTF-8 sequence
+}
+
+// a rune transcoder in either direction
+type runeStream struct {
+	transcoder *runeTranscoder
+	encrypt    bool
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// (ctor) a writer that encrypts to w using a validated key sequencer.
+func NewEncryptingWriter(w io.Writer, seq IKeySequencer) *CipherWriter {
+	return newCipherWriter(w, newRuneStream(seq, true))
+}
+
+// (ctor) a writer that decrypts to w using a validated key sequencer.
+func NewDecryptingWriter(w io.Writer, seq IKeySequencer) *CipherWriter {
+	return newCipherWriter(w, newRuneStream(seq, false))
+}
+
+// (ctor) a reader that encrypts what it reads from r using a
+// validated key sequencer.
+func NewEncryptingReader(r io.Reader, seq IKeySequencer) *CipherReader {
+	return &CipherReader{
+		r:      norm.NFC.Reader(r),
+		stream: newRuneStream(seq, true),
+	}
+}
+
+// (ctor) a reader that decrypts what it reads from r using a
+// validated key sequencer.
+func NewDecryptingReader(r io.Reader, seq IKeySequencer) *CipherReader {
+	return &CipherReader{
+		r:      norm.NFC.Reader(r),
+		stream: newRuneStream(seq, false),
+	}
+}
+
+func newCipherWriter(w io.Writer, stream runeStream) *CipherWriter {
+	sink := &transcodingWriter{
+		w:      w,
+		stream: stream,
+	}
+	return &CipherWriter{
+		nfc:  norm.NFC.Writer(sink),
+		sink: sink,
+	}
+}
+
+func newRuneStream(seq IKeySequencer, encrypt bool) runeStream {
+	return runeStream{
+		transcoder: NewCaesarCipherFromSequencer(seq).newRuneTranscoder(),
+		encrypt:    encrypt,
+	}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// implements io.Writer. It returns len(p) unless the underlying
+// writer fails. Characters that may still combine with the next
+// write, e.g. a trailing incomplete UTF-8 sequence, are held back.
+func (cw *CipherWriter) Write(p []byte) (int, error) {
+	return cw.nfc.Write(p)
+}
+
+// implements io.Closer. Flushes the held back characters to the
+// underlying writer, which is not closed.
+func (cw *CipherWriter) Close() error {
+	if err := cw.nfc.Close(); err != nil {
+		return err
+	}
+
+	return cw.sink.flush()
+}
+
+// implements io.Reader
+func (cr *CipherReader) Read(p []byte) (int, error) {
+	chunk := make([]byte, streamChunkSize)
+	for cr.out.Len() == 0 && cr.err == nil {
+		n, err := cr.r.Read(chunk)
+		cr.err = err
+		cr.pending = cr.stream.transcode(&cr.out, append(cr.pending, chunk[:n]...), err != nil)
+	}
+
+	if cr.out.Len() != 0 {
+		return cr.out.Read(p)
+	}
+
+	return 0, cr.err
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// implements io.Writer for the normalizer of the CipherWriter
+func (tw *transcodingWriter) Write(p []byte) (int, error) {
+	var out bytes.Buffer
+	tw.pending = tw.stream.transcode(&out, append(tw.pending, p...), false)
+	if _, err := tw.w.Write(out.Bytes()); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// writes the incomplete UTF-8 sequence (if any) as-is
+func (tw *transcodingWriter) flush() error {
+	if len(tw.pending) == 0 {
+		return nil
+	}
+
+	_, err := tw.w.Write(tw.pending)
+	tw.pending = nil
+	return err
+}
+
+// transcodes all complete runes of data into out and returns the
+// bytes of the incomplete trailing UTF-8 sequence, unless final.
+func (rs runeStream) transcode(out *bytes.Buffer, data []byte, final bool) []byte {
+	for len(data) > 0 {
+		if !final && !utf8.FullRune(data) {
+			break
+		}
+
+		r, size := utf8.DecodeRune(data)
+		if r == utf8.RuneError && size <= 1 {
+			// · invalid UTF-8, pass the byte through untouched
+			out.WriteByte(data[0])
+			data = data[1:]
+			continue
+		}
+
+		if rs.encrypt {
+			out.WriteRune(rs.transcoder.encode(r))
+		} else {
+			out.WriteRune(rs.transcoder.decode(r))
+		}
+		data = data[size:]
+	}
+
+	return bytes.Clone(data)
+}
@@ -79,17 +79,23 @@ func (ds *DidimusSequencer) String() string {
 }
 
 func (ds *DidimusSequencer) Validate() error {
+	if err := ds.params.checkCipherAlpha(); err != nil {
+		return err
+	}
+
 	if useCORRECTORS {
 		shfM, shfO, shfAlt, warn := DidimusCorrection(ds.params.KeyValue, ds.params.Offset, ds.params.Alphabet)
 		if shfM != ds.params.KeyValue {
 			ds.params.KeyValue = shfM
 		}
-		if shfO != ds.params.KeyValue {
+		if shfO != ds.params.Offset {
 			ds.params.Offset = shfO
 		}
 		if shfAlt != ds.params.altKey {
 			ds.params.altKey = shfAlt
 		}
+		// NextKey() uses the alternate key of the sequencer
+		ds.altKey = shfAlt
 		return warn
 	} else {
 		err := ds.CaesarSequencer.Validate()
@@ -127,6 +133,30 @@ func (ds *DidimusSequencer) GetParams() *CaesarParameters {
 	return ds.params
 }
 
+// rewinds the key sequence to the start of a message
+func (ds *DidimusSequencer) Reset() {
+	ds.isEvenPosition = false
+}
+
+// an independent copy at the same position of the key sequence
+func (ds *DidimusSequencer) Clone() IKeySequencer {
+	clone := *ds
+	clone.CaesarSequencer = ds.CaesarSequencer.cloneBase()
+	return &clone
+}
+
+// the next key is the main key for even and the alternate key for
+// odd positions.
+func (ds *DidimusSequencer) SeekTo(n int) error {
+	if err := checkSeekPosition(n); err != nil {
+		return err
+	}
+
+	// NextKey() toggles before use, so the previous one was odd
+	ds.isEvenPosition = n%2 == 1
+	return nil
+}
+
 func (ds *DidimusSequencer) NextKey() int {
 	var keyShift int = 0
 	ds.isEvenPosition = !ds.isEvenPosition // toggle, first (0) is Even
@@ -0,0 +1,126 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * The operation used to combine the plain character with the key
+ * shift. It is independent of the key sequencer, therefore every
+ * cipher mode can be used in standard (shift forward), Beaufort or
+ * Variant Beaufort style. With P the plain character index, C the
+ * ciphered character index, K the key shift and N alphabet length:
+ *	Standard			C = P + K (mod N)	P = C - K (mod N)
+ *	Beaufort			C = K - P (mod N)	P = K - C (mod N)
+ *	Variant Beaufort	C = P - K (mod N)	P = C + K (mod N)
+ * Beaufort is reciprocal, encryption and decryption are the same.
+ *-----------------------------------------------------------------*/
+package cipher
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+const (
+	StandardDirection Direction = iota
+	BeaufortDirection
+	VariantBeaufortDirection
+)
+
+// the sequencers with their own tabula (Affine, Atbash) have no
+// Beaufort variants
+var ErrDirectionUnsupported error = errors.New("the cipher mode only supports the standard direction")
+
+var directionToString map[Direction]string = map[Direction]string{
+	StandardDirection:        "Standard",
+	BeaufortDirection:        "Beaufort",
+	VariantBeaufortDirection: "Variant Beaufort",
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// the operation used to combine plain character and key shift
+type Direction uint8
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// implements fmt.Stringer
+func (d Direction) String() string {
+	if name, ok := directionToString[d]; ok {
+		return name
+	}
+	return fmt.Sprintf("Direction(%d)", d)
+}
+
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// the direction of a sequencer that builds its own tabula
+func (d Direction) checkStandard() error {
+	if d != StandardDirection {
+		return ErrDirectionUnsupported
+	}
+	return nil
+}
+
+// the cipher ring position C of the plain position P with the key
+// shift K, the same as position P of CipherTabula().
+func (d Direction) cipherPosition(p, k, n int) int {
+	switch d {
+	case BeaufortDirection:
+		return (((k - p) % n) + n) % n
+	case VariantBeaufortDirection:
+		return (((p - k) % n) + n) % n
+	default:
+		return (((p + k) % n) + n) % n
+	}
+}
+
+// the plain position P of the cipher ring position C with the key
+// shift K, the inverse of cipherPosition().
+func (d Direction) plainPosition(c, k, n int) int {
+	switch d {
+	case BeaufortDirection:
+		return (((k - c) % n) + n) % n
+	case VariantBeaufortDirection:
+		return (((c + k) % n) + n) % n
+	default:
+		return (((c - k) % n) + n) % n
+	}
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+// Builds the ciphered tabula for the given key shift and direction.
+// The character at position P of the returned tabula is the
+// encryption of the character at position P of the alphabet.
+func CipherTabula(alphabet string, shift int, dir Direction) string {
+	switch dir {
+	case BeaufortDirection:
+		runic := []rune(alphabet)
+		N := len(runic)
+		tabula := make([]rune, N)
+		for i := range N {
+			tabula[i] = runic[(((shift-i)%N)+N)%N]
+		}
+		return string(tabula)
+
+	case VariantBeaufortDirection:
+		N := utf8.RuneCountInString(alphabet)
+		return RotateStringLeft(alphabet, N-(shift%N))
+
+	default:
+		return RotateStringLeft(alphabet, shift)
+	}
+}
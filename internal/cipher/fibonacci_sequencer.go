@@ -6,25 +6,30 @@
  * Caesar key sequencer for Fibonacci mode. It uses the main Caesar
  * key and for each subsequent encodeable character in the input, it
  * uses a new key made by adding the main key to the current Fibonacci
- * term. The algorithm uses a 10-term Fibonacci series. The effective
- * key shift is always normalized to the current alphabet.
- * Version: 1
+ * term. By default the algorithm uses a 10-term Fibonacci series, but
+ * the number of terms and the two seeds can be configured, making it
+ * a generalized (Lucas) sequence computed modulo the alphabet length.
+ * The effective key shift is always normalized to the current alphabet.
+ * Version: 2
  * Class: Caesar (substitution cipher)
  * Mode : Fibonacci
- * Type : Polyalphabetic cipher (up to 9)
+ * Type : Polyalphabetic cipher (up to Terms)
  *-----------------------------------------------------------------*/
 package cipher
 
 import (
 	"fmt"
+
+	"github.com/lordofscripts/caesardisk"
 )
 
 /* ----------------------------------------------------------------
  *						G l o b a l s
  *-----------------------------------------------------------------*/
 
-// we use a 10-term Fibonacci sequence for the Fibonacci Key Sequencer
-var fibonacci []int = []int{0, 1, 1, 2, 3, 5, 8, 13, 21, 34}
+// By default we use a 10-term Fibonacci sequence {0,1,1,2,3,5,8,13,21,34}
+// for the Fibonacci Key Sequencer
+var DefaultFibonacciSeries = FibonacciSeries{Terms: 10, Seed0: 0, Seed1: 1}
 
 /* ----------------------------------------------------------------
  *				I n t e r f a c e s
@@ -36,12 +41,23 @@ var _ IKeySequencer = (*FibonacciSequencer)(nil)
  *				P u b l i c		T y p e s
  *-----------------------------------------------------------------*/
 
+// The generalized Fibonacci (Lucas) series F(0)=Seed0, F(1)=Seed1 and
+// F(x)=F(x-1)+F(x-2). After Terms terms the series rewinds.
+type FibonacciSeries struct {
+	Terms int
+	Seed0 int
+	Seed1 int
+}
+
 // Fibonacci uses a poly-alphabetic sequencer in which it starts
 // with the main Caesar key, and for subsequent encodeable characters
 // it uses key+F(x) where F(x) is the Xth term of a Fibonacci series.
-// Our Fibonacci series has 10 terms.
+// Our Fibonacci series has 10 terms unless configured otherwise.
 type FibonacciSequencer struct {
 	CaesarSequencer
+	series FibonacciSeries
+	// the series terms already reduced modulo N
+	terms     []int
 	termIndex int
 }
 
@@ -50,9 +66,17 @@ type FibonacciSequencer struct {
  *-----------------------------------------------------------------*/
 
 // (Ctor) a new instance of the Caesar encoder using Fibonacci mode.
+// The series is par.Fibonacci, or DefaultFibonacciSeries when nil.
 func NewFibonacciSequencer(par *CaesarParameters) *FibonacciSequencer {
+	series := DefaultFibonacciSeries
+	if par.Fibonacci != nil {
+		series = *par.Fibonacci
+	}
+
 	return &FibonacciSequencer{
 		CaesarSequencer: *NewCaesarSequencer(par),
+		series:          series,
+		terms:           FibonacciTerms(series, par.Alphabet),
 		termIndex:       0,
 	}
 }
@@ -64,11 +88,16 @@ func NewFibonacciSequencer(par *CaesarParameters) *FibonacciSequencer {
 func (fs *FibonacciSequencer) String() string {
 	charM, _ := fs.params.Alphabet.Character(fs.params.KeyValue)
 
-	return fmt.Sprintf("Fibonacci(%c|%d,F(10))",
-		charM, fs.params.KeyValue)
+	return fmt.Sprintf("Fibonacci(%c|%d,F(%d;%d,%d))",
+		charM, fs.params.KeyValue,
+		fs.series.Terms, fs.series.Seed0, fs.series.Seed1)
 }
 
 func (fs *FibonacciSequencer) Validate() error {
+	if fs.series.Terms < 1 {
+		return fmt.Errorf("Fibonacci needs at least one term: %d", fs.series.Terms)
+	}
+
 	return fs.CaesarSequencer.Validate()
 }
 
@@ -81,14 +110,39 @@ func (fs *FibonacciSequencer) GetParams() *CaesarParameters {
 	return fs.params
 }
 
+// rewinds the key sequence to the start of a message
+func (fs *FibonacciSequencer) Reset() {
+	fs.termIndex = 0
+}
+
+// an independent copy at the same position of the key sequence
+func (fs *FibonacciSequencer) Clone() IKeySequencer {
+	clone := *fs
+	clone.CaesarSequencer = fs.CaesarSequencer.cloneBase()
+	return &clone
+}
+
+// the series rewinds after the last term, so only n mod Terms matters
+func (fs *FibonacciSequencer) SeekTo(n int) error {
+	if err := checkSeekPosition(n); err != nil {
+		return err
+	}
+	if len(fs.terms) == 0 {
+		return fmt.Errorf("Fibonacci needs at least one term: %d", fs.series.Terms)
+	}
+
+	fs.termIndex = n % len(fs.terms)
+	return nil
+}
+
 func (fs *FibonacciSequencer) NextKey() int {
 	// ensure we wrap correctly
 	_, max := fs.CaesarSequencer.KeyRange()
 	// new shift but wrapped to domain
-	keyShift := (fs.params.KeyValue + fibonacci[fs.termIndex]) % (max + 1)
+	keyShift := (fs.params.KeyValue + fs.terms[fs.termIndex]) % (max + 1)
 	// now update term for next call
 	newIndex := fs.termIndex + 1
-	if newIndex >= len(fibonacci) {
+	if newIndex >= len(fs.terms) {
 		newIndex = 0
 	}
 	fs.termIndex = newIndex
@@ -98,7 +152,7 @@ func (fs *FibonacciSequencer) NextKey() int {
 
 // The internal key schedule
 func (cs *FibonacciSequencer) GetRawKeySchedule() []KeyScheduleItemInt {
-	qty := len(fibonacci)
+	qty := len(cs.terms)
 	fakeSeq := NewFibonacciSequencer(cs.params)
 	schedule := make([]KeyScheduleItemInt, qty)
 	for i := range qty {
@@ -119,3 +173,30 @@ func (fs *FibonacciSequencer) IsPolyalphabetic() bool {
 func (fs *FibonacciSequencer) IsOffsetRequired() bool {
 	return false
 }
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+// Computes the terms of the generalized Fibonacci series modulo the
+// alphabet length N, therefore the terms never overflow.
+func FibonacciTerms(series FibonacciSeries, alpha *caesardisk.AlphabetModel) []int {
+	if series.Terms < 1 {
+		return []int{}
+	}
+
+	N := alpha.Length()
+	terms := make([]int, series.Terms)
+	for x := range series.Terms {
+		switch x {
+		case 0:
+			terms[x] = ((series.Seed0 % N) + N) % N
+		case 1:
+			terms[x] = ((series.Seed1 % N) + N) % N
+		default:
+			terms[x] = (terms[x-1] + terms[x-2]) % N
+		}
+	}
+
+	return terms
+}
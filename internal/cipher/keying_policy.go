@@ -0,0 +1,56 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * The keying policy determines which characters of the message
+ * advance the key sequence. By default only the characters that are
+ * part of the alphabet do (spaces & punctuation are skipped), but some
+ * historic procedures advance the key on every character position.
+ * Foreign characters are passed through as-is in either case.
+ *-----------------------------------------------------------------*/
+package cipher
+
+import (
+	"fmt"
+)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+const (
+	// only alphabet characters advance the key (default)
+	SkipForeignKeying KeyingPolicy = iota
+	// every character position advances the key
+	CountAllKeying
+)
+
+var keyingPolicyToString map[KeyingPolicy]string = map[KeyingPolicy]string{
+	SkipForeignKeying: "Skip foreign",
+	CountAllKeying:    "Count all",
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// which characters of the message advance the key sequence
+type KeyingPolicy uint8
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// implements fmt.Stringer
+func (k KeyingPolicy) String() string {
+	if name, ok := keyingPolicyToString[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("KeyingPolicy(%d)", k)
+}
+
+// whether a character outside the alphabet advances the key
+func (k KeyingPolicy) KeysForeign() bool {
+	return k == CountAllKeying
+}
@@ -39,4 +39,39 @@ type IKeySequencer interface {
 	IsPolyalphabetic() bool
 	// whether the Offset parameter is used in key sequencing
 	IsOffsetRequired() bool
+	// rewinds the key sequence so that the next key is the first key
+	// of a message.
+	Reset()
+	// an independent copy of the sequencer at the same position of
+	// the key sequence.
+	Clone() IKeySequencer
+	// positions the sequencer so that the next key is the one of the
+	// n-th (zero-based) encodeable character of the message. Sequencers
+	// whose keys depend on the message itself (Autokey) cannot seek.
+	SeekTo(n int) error
+}
+
+// Sequencers whose key schedule depends on the message itself (autokey)
+// implement this companion interface. The transcoder feeds back every
+// encodeable character (letter or symbol of a dual disk) right after it
+// was transcoded with the key obtained from the last NextKey() call.
+// Characters that pass through take no key from these sequencers, not
+// even when all characters are keyed, since there is nothing to feed.
+type IFeedbackSequencer interface {
+	IKeySequencer
+	// the plain and ciphered characters (uppercased alphabet or symbol
+	// runes) of the character that was just encoded or decoded.
+	Feedback(plain, ciphered rune)
+}
+
+// Sequencers that do not merely shift the cipher alphabet (e.g. Affine)
+// implement this companion interface to build their own tabula. The
+// Direction does not apply to it, so their Validate() rejects the
+// Beaufort directions with ErrDirectionUnsupported.
+type ITabulaSequencer interface {
+	IKeySequencer
+	// the ciphered tabula for the given cipher alphabet and key shift.
+	// The character at position P of the tabula is the encryption of
+	// the character at position P of the plain alphabet.
+	Tabula(alphabet string, keyShift int) string
 }
@@ -6,13 +6,15 @@
  * Caesar key sequencer for Primus mode. It uses the main Caesar
  * key and for subsequent encodeable characters in the input, it uses
  * a shift composed of the Main key/shift plus the current term in the
- * list of 10 primes (2..31). After the last prime is used, it resets
- * and starts again. The effective
+ * list of primes. By default the 11 primes 2..31 but the window of
+ * primes (starting prime index and number of terms) can be configured.
+ * The primes are generated on demand. After the last prime is used,
+ * it resets and starts again. The effective
  * key shift is always normalized to the current alphabet.
- * Version: 1
+ * Version: 2
  * Class: Caesar (substitution cipher)
  * Mode : Primus
- * Type : Poly-alphabetic cipher (up to 12)
+ * Type : Poly-alphabetic cipher (up to Terms+1)
  *-----------------------------------------------------------------*/
 package cipher
 
@@ -24,9 +26,8 @@ import (
  *						G l o b a l s
  *-----------------------------------------------------------------*/
 
-// List of first primes. Although 0 is not a prime, we need to ensure
-// that the main Caesar shift/key is always the first.
-var primes []int = []int{0, 2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31}
+// By default the first 11 primes {2,3,5,7,11,13,17,19,23,29,31}
+var DefaultPrimeWindow = PrimeWindow{Start: 0, Terms: 11}
 
 /* ----------------------------------------------------------------
  *				I n t e r f a c e s
@@ -38,13 +39,23 @@ var _ IKeySequencer = (*PrimusSequencer)(nil)
  *				P u b l i c		T y p e s
  *-----------------------------------------------------------------*/
 
+// The window of consecutive primes used by Primus. Start is the
+// index of the first prime (0 is prime 2) and Terms how many primes.
+type PrimeWindow struct {
+	Start int
+	Terms int
+}
+
 // Primus is like Fibonacci but instead of a Fibonacci sequence, it
-// uses a sequence of the first 11 prime numbers as an offset to the
-// main Caesar key. Although 0 is not a prime, the first in the series
-// is always zero, thus the main Caesar key.
+// uses a sequence of prime numbers (the first 11 by default) as an
+// offset to the main Caesar key. Although 0 is not a prime, the first
+// in the series is always zero, thus the main Caesar key.
 type PrimusSequencer struct {
 	CaesarSequencer
-	// The current Prime-number term index as it progresses. (0..11)
+	window PrimeWindow
+	// zero followed by the primes in the window
+	primes []int
+	// The current Prime-number term index as it progresses. (0..Terms)
 	termIndex int
 	// The maximum number of Prime-number terms to be used. Zero
 	// is equivalent to plain Caesar, One is equivalent to Didimus
@@ -58,8 +69,15 @@ type PrimusSequencer struct {
 
 // (Ctor) a new instance of the Primus sequencer for the Caesar encoder.
 // The Offset value is automatically corrected via modulo to the maximum
-// number of prime values. When Offset is zero we select the maximum (11)
+// number of prime values. When Offset is zero we select the maximum.
+// The window is par.Primus, or DefaultPrimeWindow when nil.
 func NewPrimusSequencer(par *CaesarParameters) *PrimusSequencer {
+	window := DefaultPrimeWindow
+	if par.Primus != nil {
+		window = *par.Primus
+	}
+	primes := append([]int{0}, Primes(window.Start, window.Terms)...)
+
 	// normalize Offset, and if zero use the maximum set of primes
 	var maxPrimeTerms int = par.Offset % len(primes)
 	if par.Offset == 0 {
@@ -68,6 +86,8 @@ func NewPrimusSequencer(par *CaesarParameters) *PrimusSequencer {
 
 	return &PrimusSequencer{
 		CaesarSequencer: *NewCaesarSequencer(par),
+		window:          window,
+		primes:          primes,
 		termIndex:       0,
 		maxPrimes:       maxPrimeTerms,
 	}
@@ -80,11 +100,15 @@ func NewPrimusSequencer(par *CaesarParameters) *PrimusSequencer {
 func (ps *PrimusSequencer) String() string {
 	charM, _ := ps.params.Alphabet.Character(ps.params.KeyValue)
 
-	return fmt.Sprintf("Primus(%c|%d,P(%d))",
-		charM, ps.params.KeyValue, ps.maxPrimes)
+	return fmt.Sprintf("Primus(%c|%d,P(%d;%d+%d))",
+		charM, ps.params.KeyValue, ps.maxPrimes, ps.window.Start, ps.window.Terms)
 }
 
 func (ps *PrimusSequencer) Validate() error {
+	if ps.window.Start < 0 || ps.window.Terms < 1 {
+		return fmt.Errorf("invalid Primus prime window: start %d terms %d", ps.window.Start, ps.window.Terms)
+	}
+
 	return ps.CaesarSequencer.Validate()
 }
 
@@ -97,16 +121,39 @@ func (ps *PrimusSequencer) GetParams() *CaesarParameters {
 	return ps.params
 }
 
+// rewinds the key sequence to the start of a message
+func (ps *PrimusSequencer) Reset() {
+	ps.termIndex = 0
+}
+
+// an independent copy at the same position of the key sequence
+func (ps *PrimusSequencer) Clone() IKeySequencer {
+	clone := *ps
+	clone.CaesarSequencer = ps.CaesarSequencer.cloneBase()
+	return &clone
+}
+
+// the primes rewind after the maximum selected number of primes, so
+// only n modulo that period matters.
+func (ps *PrimusSequencer) SeekTo(n int) error {
+	if err := checkSeekPosition(n); err != nil {
+		return err
+	}
+
+	ps.termIndex = n % ps.period()
+	return nil
+}
+
 func (ps *PrimusSequencer) NextKey() int {
 	// ensure we wrap correctly
 	_, max := ps.CaesarSequencer.KeyRange()
 	// new shift but wrapped to domain
-	keyShift := (ps.params.KeyValue + primes[ps.termIndex]) % (max + 1)
+	keyShift := (ps.params.KeyValue + ps.primes[ps.termIndex]) % (max + 1)
 	// now update term for next call
 	newIndex := ps.termIndex + 1
 	// rewind when we reach the maximum number of available primes,
 	// or the maximum selected number of primes.
-	if newIndex >= len(primes) || newIndex > ps.maxPrimes {
+	if newIndex >= len(ps.primes) || newIndex > ps.maxPrimes {
 		newIndex = 0
 	}
 	ps.termIndex = newIndex
@@ -116,7 +163,7 @@ func (ps *PrimusSequencer) NextKey() int {
 
 // The internal key schedule
 func (ps *PrimusSequencer) GetRawKeySchedule() []KeyScheduleItemInt {
-	qty := len(primes)
+	qty := len(ps.primes)
 	fakeSeq := NewPrimusSequencer(ps.params)
 	schedule := make([]KeyScheduleItemInt, qty)
 	for i := range qty {
@@ -139,11 +186,52 @@ func (ps *PrimusSequencer) IsOffsetRequired() bool {
 	return true
 }
 
+/* ----------------------------------------------------------------
+ *				P r i v a t e	M e t h o d s
+ *-----------------------------------------------------------------*/
+
+// the number of keys before NextKey() rewinds. A negative maxPrimes
+// (a negative Offset) rewinds after every key, thus plain Caesar.
+func (ps *PrimusSequencer) period() int {
+	return max(1, min(len(ps.primes), ps.maxPrimes+1))
+}
+
 /* ----------------------------------------------------------------
  *					F u n c t i o n s
  *-----------------------------------------------------------------*/
 
-// The maximum number of Prime-number terms available in the algorithm.
-func PrimusMaximus() int {
-	return len(primes)
+// The maximum number of Prime-number terms available in the algorithm,
+// that is the main key plus the primes of the (default) window.
+func PrimusMaximus(window ...PrimeWindow) int {
+	if len(window) > 0 {
+		return window[0].Terms + 1
+	}
+	return DefaultPrimeWindow.Terms + 1
+}
+
+// Generates count consecutive primes starting with the prime at
+// index start (index 0 is prime 2) by trial division.
+func Primes(start, count int) []int {
+	if start < 0 || count < 1 {
+		return []int{}
+	}
+
+	primes := make([]int, 0, start+count)
+	for n := 2; len(primes) < start+count; n++ {
+		isPrime := true
+		for _, p := range primes {
+			if p*p > n {
+				break
+			}
+			if n%p == 0 {
+				isPrime = false
+				break
+			}
+		}
+		if isPrime {
+			primes = append(primes, n)
+		}
+	}
+
+	return primes[start:]
 }
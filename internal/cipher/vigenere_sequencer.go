@@ -0,0 +1,168 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Caesar key sequencer for Vigenère mode. Instead of a fixed table of
+ * offsets over the main key, it walks the letters of a keyword. Each
+ * keyword letter is mapped through the current alphabet to obtain its
+ * key shift (A=0, B=1, ...). Keyword characters that are not part of
+ * the alphabet are skipped. As with the other sequencers, the key only
+ * advances on encodeable characters of the message.
+ * Version: 1
+ * Class: Caesar (substitution cipher)
+ * Mode : Vigenère
+ * Type : Polyalphabetic cipher (keyword length)
+ *-----------------------------------------------------------------*/
+package cipher
+
+import (
+	"fmt"
+
+	"github.com/lordofscripts/caesardisk"
+)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+/* ----------------------------------------------------------------
+ *				I n t e r f a c e s
+ *-----------------------------------------------------------------*/
+
+var _ IKeySequencer = (*VigenereSequencer)(nil)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// Vigenère uses a poly-alphabetic sequencer in which each encodeable
+// character uses the key shift of the next letter of the keyword.
+// After the last keyword letter it rewinds. The main key is not used.
+type VigenereSequencer struct {
+	CaesarSequencer
+	// the key shifts derived from the keyword letters
+	shifts []int
+	// The current keyword letter index as it progresses.
+	termIndex int
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// (Ctor) a new instance of the Vigenère sequencer for the Caesar encoder.
+// The keyword is taken from par.Keyword and mapped through par.Alphabet.
+func NewVigenereSequencer(par *CaesarParameters) *VigenereSequencer {
+	return &VigenereSequencer{
+		CaesarSequencer: *NewCaesarSequencer(par),
+		shifts:          KeywordShifts(par.Keyword, par.Alphabet),
+		termIndex:       0,
+	}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+func (vs *VigenereSequencer) String() string {
+	return fmt.Sprintf("Vigenère(%s,K(%d))", vs.params.Keyword, len(vs.shifts))
+}
+
+// A keyword is mandatory and at least one of its characters must
+// be part of the alphabet. The main key is not used by Vigenère.
+func (vs *VigenereSequencer) Validate() error {
+	if err := vs.params.checkCipherAlpha(); err != nil {
+		return err
+	}
+	if len(vs.shifts) == 0 {
+		return fmt.Errorf("Vigenère needs a keyword with letters of the alphabet: '%s'", vs.params.Keyword)
+	}
+
+	vs.isValid = true
+	return nil
+}
+
+// The valid range of Vigenère keys.
+func (vs *VigenereSequencer) KeyRange() (min, max int) {
+	return vs.CaesarSequencer.KeyRange()
+}
+
+func (vs *VigenereSequencer) GetParams() *CaesarParameters {
+	return vs.params
+}
+
+// rewinds the key sequence to the start of a message
+func (vs *VigenereSequencer) Reset() {
+	vs.termIndex = 0
+}
+
+// an independent copy at the same position of the key sequence
+func (vs *VigenereSequencer) Clone() IKeySequencer {
+	clone := *vs
+	clone.CaesarSequencer = vs.CaesarSequencer.cloneBase()
+	return &clone
+}
+
+// the keyword rewinds after its last letter
+func (vs *VigenereSequencer) SeekTo(n int) error {
+	if err := checkSeekPosition(n); err != nil {
+		return err
+	}
+	if len(vs.shifts) == 0 {
+		return fmt.Errorf("Vigenère cannot seek without keyword: '%s'", vs.params.Keyword)
+	}
+
+	vs.termIndex = n % len(vs.shifts)
+	return nil
+}
+
+func (vs *VigenereSequencer) NextKey() int {
+	keyShift := vs.shifts[vs.termIndex]
+	// now update term for next call
+	vs.termIndex = (vs.termIndex + 1) % len(vs.shifts)
+
+	return keyShift
+}
+
+// The internal key schedule, one entry per keyword letter
+func (vs *VigenereSequencer) GetRawKeySchedule() []KeyScheduleItemInt {
+	qty := len(vs.shifts)
+	fakeSeq := NewVigenereSequencer(vs.params)
+	schedule := make([]KeyScheduleItemInt, qty)
+	for i := range qty {
+		schedule[i] = KeyScheduleItemInt{
+			KeyShift: fakeSeq.NextKey(),
+			Comment:  fmt.Sprintf("#%d", i)}
+	}
+	return schedule
+}
+
+// Vigenère is a polyalphabetic substitution cipher
+func (vs *VigenereSequencer) IsPolyalphabetic() bool {
+	return true
+}
+
+// whether the Offset parameter is used in key sequencing.
+// Vigenère uses a keyword instead.
+func (vs *VigenereSequencer) IsOffsetRequired() bool {
+	return false
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+// Maps each letter of the keyword to its key shift in the given
+// alphabet. The search is case-insensitive and characters that are
+// not part of the alphabet are skipped.
+func KeywordShifts(keyword string, alpha *caesardisk.AlphabetModel) []int {
+	shifts := make([]int, 0, len(keyword))
+	for _, char := range alpha.Normalize(keyword) {
+		if at := alpha.Find(char); at != -1 {
+			shifts = append(shifts, at)
+		}
+	}
+
+	return shifts
+}
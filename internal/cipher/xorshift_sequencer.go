@@ -0,0 +1,174 @@
+/* -----------------------------------------------------------------
+ *					L o r d  O f   S c r i p t s (tm)
+ *				  Copyright (C)2025 Dídimo Grimaldo T.
+ *							   goCaesarDisk
+ * - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+ * Caesar key sequencer for Xorshift mode. The key stream comes from a
+ * xorshift64* pseudo-random generator (Marsaglia/Vigna) whose 64-bit
+ * state is seeded from the main key, the Offset and an (optional)
+ * passphrase. Each encodeable character uses the main key plus the
+ * next generator output modulo the alphabet length N:
+ *	x ^= x >> 12; x ^= x << 25; x ^= x >> 27; out = x * 2685821657736338717
+ *	K(i) = (Key + (out >> 32) mod N) mod N
+ * The seed is XXH64(passphrase, 0xDEADBEA7) XOR (Key << 32 | Offset)
+ * and a zero seed is replaced by 0x9E3779B97F4A7C15. The period is
+ * 2^64-1, so the schedule does not repeat within any practical message.
+ * It is NOT cryptographically secure, merely a long-period Caesar.
+ * Version: 1
+ * Class: Caesar (substitution cipher)
+ * Mode : Xorshift
+ * Type : Poly-alphabetic cipher (2^64-1)
+ *-----------------------------------------------------------------*/
+package cipher
+
+import (
+	"fmt"
+
+	"github.com/lordofscripts/caesardisk/internal/hash"
+)
+
+/* ----------------------------------------------------------------
+ *						G l o b a l s
+ *-----------------------------------------------------------------*/
+
+const (
+	xorshiftHashSeed   uint64 = 0xDEADBEA7
+	xorshiftZeroSeed   uint64 = 0x9E3779B97F4A7C15
+	xorshiftMultiplier uint64 = 0x2545F4914F6CDD1D
+)
+
+/* ----------------------------------------------------------------
+ *				I n t e r f a c e s
+ *-----------------------------------------------------------------*/
+
+var _ IKeySequencer = (*XorshiftSequencer)(nil)
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		T y p e s
+ *-----------------------------------------------------------------*/
+
+// Xorshift is a poly-alphabetic sequencer whose key stream comes from
+// a seeded xorshift64* generator. Same seed, same key stream.
+type XorshiftSequencer struct {
+	CaesarSequencer
+	state uint64
+}
+
+/* ----------------------------------------------------------------
+ *				C o n s t r u c t o r s
+ *-----------------------------------------------------------------*/
+
+// (Ctor) a new instance of the Xorshift sequencer. The generator is
+// seeded with par.KeyValue, par.Offset and the par.Keyword passphrase.
+func NewXorshiftSequencer(par *CaesarParameters) *XorshiftSequencer {
+	return &XorshiftSequencer{
+		CaesarSequencer: *NewCaesarSequencer(par),
+		state:           XorshiftSeed(par.KeyValue, par.Offset, par.Keyword),
+	}
+}
+
+/* ----------------------------------------------------------------
+ *				P u b l i c		M e t h o d s
+ *-----------------------------------------------------------------*/
+
+func (xs *XorshiftSequencer) String() string {
+	charM, _ := xs.params.Alphabet.Character(xs.params.KeyValue)
+
+	return fmt.Sprintf("Xorshift(%c|%d,%d)", charM, xs.params.KeyValue, xs.params.Offset)
+}
+
+// Corrects the main key like plain Caesar. Since the seed depends on
+// the key, the generator is re-seeded with the corrected value.
+func (xs *XorshiftSequencer) Validate() error {
+	warn := xs.CaesarSequencer.Validate()
+	xs.state = XorshiftSeed(xs.params.KeyValue, xs.params.Offset, xs.params.Keyword)
+
+	return warn
+}
+
+// The valid range of Xorshift keys.
+func (xs *XorshiftSequencer) KeyRange() (min, max int) {
+	return xs.CaesarSequencer.KeyRange()
+}
+
+func (xs *XorshiftSequencer) GetParams() *CaesarParameters {
+	return xs.params
+}
+
+// re-seeds the generator to the start of a message
+func (xs *XorshiftSequencer) Reset() {
+	xs.state = XorshiftSeed(xs.params.KeyValue, xs.params.Offset, xs.params.Keyword)
+}
+
+// an independent copy at the same position of the key sequence
+func (xs *XorshiftSequencer) Clone() IKeySequencer {
+	clone := *xs
+	clone.CaesarSequencer = xs.CaesarSequencer.cloneBase()
+	return &clone
+}
+
+// the generator is re-seeded and advanced n steps
+func (xs *XorshiftSequencer) SeekTo(n int) error {
+	if err := checkSeekPosition(n); err != nil {
+		return err
+	}
+
+	xs.Reset()
+	for range n {
+		xs.NextKey()
+	}
+	return nil
+}
+
+func (xs *XorshiftSequencer) NextKey() int {
+	N := uint64(xs.params.Alphabet.Length())
+	// xorshift64*
+	xs.state ^= xs.state >> 12
+	xs.state ^= xs.state << 25
+	xs.state ^= xs.state >> 27
+	out := xs.state * xorshiftMultiplier
+
+	return int((uint64(xs.params.KeyValue) + (out>>32)%N) % N)
+}
+
+// The internal key schedule. Since the key stream practically never
+// repeats, only a preview of the first N (alphabet length) keys is given.
+func (xs *XorshiftSequencer) GetRawKeySchedule() []KeyScheduleItemInt {
+	qty := xs.params.Alphabet.Length()
+	fakeSeq := NewXorshiftSequencer(xs.params)
+	schedule := make([]KeyScheduleItemInt, qty)
+	for i := range qty {
+		schedule[i] = KeyScheduleItemInt{
+			KeyShift: fakeSeq.NextKey(),
+			Comment:  fmt.Sprintf("#%d", i)}
+	}
+	schedule[qty-1].Comment += " ..."
+	return schedule
+}
+
+// Xorshift is a polyalphabetic substitution cipher
+func (xs *XorshiftSequencer) IsPolyalphabetic() bool {
+	return true
+}
+
+// whether the Offset parameter is used in key sequencing.
+// Xorshift uses the Offset as part of the seed.
+func (xs *XorshiftSequencer) IsOffsetRequired() bool {
+	return true
+}
+
+/* ----------------------------------------------------------------
+ *					F u n c t i o n s
+ *-----------------------------------------------------------------*/
+
+// The 64-bit initial state of the Xorshift generator. It is never zero.
+func XorshiftSeed(keyValue, offset int, passphrase string) uint64 {
+	h := hash.NewXXH64(xorshiftHashSeed)
+	h.Update([]byte(passphrase))
+	seed := h.Digest() ^ (uint64(uint32(keyValue))<<32 | uint64(uint32(offset)))
+	if seed == 0 {
+		seed = xorshiftZeroSeed
+	}
+
+	return seed
+}
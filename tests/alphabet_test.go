@@ -0,0 +1,35 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/lordofscripts/caesardisk"
+	"github.com/lordofscripts/caesardisk/crypto"
+)
+
+// The lower case of ẞ is ß but not the other way around, the transcoder
+// must still find ß in the German alphabet.
+func Test_SharpSUpperCase(t *testing.T) {
+	alpha := caesardisk.AlphabetFactory("DE")
+	if alpha.Find('ß') == -1 || alpha.Find('ß') != alpha.Find('ẞ') {
+		t.Errorf("ß at %d ẞ at %d", alpha.Find('ß'), alpha.Find('ẞ'))
+	}
+
+	ctrl := crypto.NewCipherController(alpha, nil)
+	for _, v := range []struct {
+		Plain  string
+		Expect string
+	}{
+		{"ß", "a"},
+		{"ẞ", "A"},
+		{"grüße", "hsßaf"},
+	} {
+		got, err := ctrl.Encrypt(crypto.CaesarMode, v.Plain, 1)
+		if err != nil || got != v.Expect {
+			t.Errorf("%q Exp:%q Got:%q %v", v.Plain, v.Expect, got, err)
+		}
+		if plain, _ := ctrl.Decrypt(crypto.CaesarMode, got, 1); plain != v.Plain {
+			t.Errorf("%q round trip Got:%q", v.Plain, plain)
+		}
+	}
+}
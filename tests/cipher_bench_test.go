@@ -0,0 +1,36 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lordofscripts/caesardisk"
+	"github.com/lordofscripts/caesardisk/crypto"
+)
+
+// about one megabyte of mixed letters, spaces and punctuation
+var benchText string = strings.Repeat("Gallia est omnis divisa in partes tres, quarum unam incolunt Belgae. ", 15_000)
+
+func benchmarkMode(b *testing.B, mode crypto.CaesarCipherMode, decrypt bool, args ...any) {
+	ctrl := crypto.NewCipherController(caesardisk.AlphabetFactory("English"), nil)
+	input := benchText
+	if decrypt {
+		input, _ = ctrl.Encrypt(mode, benchText, 7, args...)
+	}
+
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+	for range b.N {
+		if decrypt {
+			ctrl.Decrypt(mode, input, 7, args...)
+		} else {
+			ctrl.Encrypt(mode, input, 7, args...)
+		}
+	}
+}
+
+func Benchmark_EncryptCaesar(b *testing.B)    { benchmarkMode(b, crypto.CaesarMode, false) }
+func Benchmark_EncryptFibonacci(b *testing.B) { benchmarkMode(b, crypto.FibonacciMode, false) }
+func Benchmark_DecryptFibonacci(b *testing.B) { benchmarkMode(b, crypto.FibonacciMode, true) }
+func Benchmark_EncryptPrimus(b *testing.B)    { benchmarkMode(b, crypto.PrimusMode, false, 0) }
+func Benchmark_DecryptPrimus(b *testing.B)    { benchmarkMode(b, crypto.PrimusMode, true, 0) }
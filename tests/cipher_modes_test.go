@@ -0,0 +1,892 @@
+package tests
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"github.com/lordofscripts/caesardisk"
+	"github.com/lordofscripts/caesardisk/crypto"
+	"github.com/lordofscripts/caesardisk/engine"
+	"github.com/lordofscripts/caesardisk/internal/cipher"
+	"golang.org/x/text/unicode/norm"
+)
+
+// The textbook Vigenère example. Non-alphabet characters are passed
+// through and do not advance the keyword.
+func Test_VigenereMode(t *testing.T) {
+	WithAlphabet := caesardisk.AlphabetFactory("English")
+	ctrl := crypto.NewCipherController(WithAlphabet, nil)
+
+	vectors := []struct {
+		Keyword string
+		Plain   string
+		Cipher  string
+	}{
+		{"LEMON", "ATTACKATDAWN", "LXFOPVEFRNHR"},
+		{"lemon", "Attack at dawn!", "Lxfopv ef rnhr!"},
+	}
+
+	for i, v := range vectors {
+		got, err := ctrl.Encrypt(crypto.VigenereMode, v.Plain, 0, v.Keyword)
+		if err != nil {
+			t.Fatalf("#%d unexpected error: %s", i+1, err)
+		}
+		if got != v.Cipher {
+			t.Errorf("#%d Exp:'%s' Got:'%s'", i+1, v.Cipher, got)
+		}
+		plain, _ := ctrl.Decrypt(crypto.VigenereMode, got, 0, v.Keyword)
+		if plain != v.Plain {
+			t.Errorf("#%d round-trip Exp:'%s' Got:'%s'", i+1, v.Plain, plain)
+		}
+	}
+
+	if _, err := ctrl.Encrypt(crypto.VigenereMode, "Hello", 0, "123"); err == nil {
+		t.Error("expected error for a keyword without alphabet letters")
+	}
+}
+
+// Autokey with plaintext and ciphertext feedback must round-trip, and
+// the plaintext variant matches the textbook example.
+func Test_AutokeyMode(t *testing.T) {
+	WithAlphabet := caesardisk.AlphabetFactory("English")
+	ctrl := crypto.NewCipherController(WithAlphabet, nil)
+
+	got, err := ctrl.Encrypt(crypto.AutokeyMode, "ATTACK AT DAWN", 0, "QUEENLY")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != "QNXEPV YT WTWP" {
+		t.Errorf("Exp:'%s' Got:'%s'", "QNXEPV YT WTWP", got)
+	}
+
+	const PLAIN = "Veni, vidi, vici."
+	for _, mode := range []crypto.CaesarCipherMode{crypto.AutokeyMode, crypto.AutokeyCipherMode} {
+		for _, primer := range []any{"KEY", nil} {
+			args := []any{}
+			if primer != nil {
+				args = append(args, primer)
+			}
+			enc, _ := ctrl.Encrypt(mode, PLAIN, 7, args...)
+			dec, _ := ctrl.Decrypt(mode, enc, 7, args...)
+			if dec != PLAIN {
+				t.Errorf("%s primer:%v round-trip Exp:'%s' Got:'%s' (%s)", mode, primer, PLAIN, dec, enc)
+			}
+		}
+	}
+
+	// Keying all characters, the space still takes no Autokey key since
+	// it is not fed back: A+K=K, B+A=B and C+B=D. The space of the dual
+	// disk is a symbol, which takes B and feeds itself back.
+	ctrl.SetKeyingPolicy(crypto.CountAllKeying)
+	dual := caesardisk.DualSymbolsFor(WithAlphabet)
+	for _, v := range []struct {
+		Mode    crypto.CaesarCipherMode
+		Symbols *caesardisk.AlphabetModel
+		Plain   string
+		Expect  string
+	}{
+		{crypto.AutokeyMode, nil, "AB C", "KB D"},
+		{crypto.AutokeyCipherMode, nil, "AB C", "KL N"},
+		{crypto.AutokeyMode, dual, "AB C", "KB0Q"},
+	} {
+		ctrl.SetSymbolAlphabet(v.Symbols)
+		got, _ := ctrl.Encrypt(v.Mode, v.Plain, 0, "K")
+		if got != v.Expect {
+			t.Errorf("%s count-all Exp:'%s' Got:'%s'", v.Mode, v.Expect, got)
+		}
+		if dec, _ := ctrl.Decrypt(v.Mode, got, 0, "K"); dec != v.Plain {
+			t.Errorf("%s count-all round-trip Exp:'%s' Got:'%s'", v.Mode, v.Plain, dec)
+		}
+	}
+	ctrl.SetSymbolAlphabet(nil)
+	primed, _ := ctrl.Encrypt(crypto.AutokeyMode, "A B C", 0, "KEY")
+	if part, err := ctrl.DecryptFragment(crypto.AutokeyMode, primed, 4, 5, 0, "KEY"); err != nil || part != "C" {
+		t.Errorf("count-all fragment Exp:'C' Got:'%s' %v", part, err)
+	}
+}
+
+// Beaufort is reciprocal in every mode, and Variant Beaufort is the
+// inverse of the standard direction.
+func Test_BeaufortDirections(t *testing.T) {
+	WithAlphabet := caesardisk.AlphabetFactory("English")
+	ctrl := crypto.NewCipherController(WithAlphabet, nil)
+
+	ctrl.SetDirection(crypto.BeaufortDirection)
+	got, _ := ctrl.Encrypt(crypto.VigenereMode, "DEFENDTHEEASTWALLOFTHECASTLE", 0, "FORTIFICATION")
+	if got != "CKMPVCPVWPIWUJOGIUAPVWRIWUUK" {
+		t.Errorf("Exp:'%s' Got:'%s'", "CKMPVCPVWPIWUJOGIUAPVWRIWUUK", got)
+	}
+
+	const PLAIN = "Alea iacta est!"
+	vectors := []struct {
+		Mode crypto.CaesarCipherMode
+		Args []any
+	}{
+		{crypto.CaesarMode, nil},
+		{crypto.DidimusMode, []any{3}},
+		{crypto.FibonacciMode, nil},
+		{crypto.PrimusMode, nil},
+	}
+
+	for i, v := range vectors {
+		ctrl.SetDirection(crypto.BeaufortDirection)
+		enc, _ := ctrl.Encrypt(v.Mode, PLAIN, 5, v.Args...)
+		again, _ := ctrl.Encrypt(v.Mode, enc, 5, v.Args...)
+		if again != PLAIN {
+			t.Errorf("#%d %s Beaufort not reciprocal: '%s' -> '%s'", i+1, v.Mode, enc, again)
+		}
+
+		ctrl.SetDirection(crypto.StandardDirection)
+		std, _ := ctrl.Encrypt(v.Mode, PLAIN, 5, v.Args...)
+		ctrl.SetDirection(crypto.VariantBeaufortDirection)
+		vari, _ := ctrl.Decrypt(v.Mode, PLAIN, 5, v.Args...)
+		if std != vari {
+			t.Errorf("#%d %s Variant Beaufort decryption should equal standard encryption: '%s' != '%s'", i+1, v.Mode, std, vari)
+		}
+	}
+
+	// the modes with their own tabula have no Beaufort variants
+	for _, dir := range []crypto.CipherDirection{crypto.BeaufortDirection, crypto.VariantBeaufortDirection} {
+		ctrl.SetDirection(dir)
+		if _, err := ctrl.Encrypt(crypto.AffineMode, PLAIN, 5, 3); !errors.Is(err, engine.ErrDirectionUnsupported) {
+			t.Errorf("Affine %s Exp:'%v' Got:'%v'", dir, engine.ErrDirectionUnsupported, err)
+		}
+		if _, err := ctrl.Decrypt(crypto.AtbashMode, PLAIN, 0); !errors.Is(err, engine.ErrDirectionUnsupported) {
+			t.Errorf("Atbash %s Exp:'%v' Got:'%v'", dir, engine.ErrDirectionUnsupported, err)
+		}
+	}
+}
+
+// A keyword-mixed alphabet keeps the character set of its base, and
+// a mixed cipher ring round-trips in every direction.
+func Test_KeyedAlphabet(t *testing.T) {
+	WithAlphabet := caesardisk.AlphabetFactory("English")
+
+	mixed := caesardisk.NewKeyedAlphabet(WithAlphabet, "Zebras!")
+	if mixed.String() != "ZEBRASCDFGHIJKLMNOPQTUVWXY" {
+		t.Errorf("Exp:'%s' Got:'%s'", "ZEBRASCDFGHIJKLMNOPQTUVWXY", mixed.String())
+	}
+	if !mixed.IsPermutationOf(WithAlphabet) {
+		t.Error("mixed alphabet must be a permutation of its base")
+	}
+
+	// a cipher ring that is not a permutation is rejected by every mode
+	par := cipher.NewCaesarParameters(WithAlphabet)
+	par.KeyValue, par.Offset, par.Keyword = 3, 2, "LEMON"
+	par.CipherAlphabet = caesardisk.NewAlphabetModelCased("ABC")
+	for _, seq := range []cipher.IKeySequencer{
+		cipher.NewCaesarSequencer(par),
+		cipher.NewDidimusSequencer(par),
+		cipher.NewVigenereSequencer(par),
+		cipher.NewAutokeySequencer(par, false),
+		cipher.NewAffineSequencer(par),
+	} {
+		if err := seq.Validate(); err != caesardisk.ErrNotPermutation {
+			t.Errorf("%s Exp:'%v' Got:'%v'", seq, caesardisk.ErrNotPermutation, err)
+		}
+	}
+
+	ctrl := crypto.NewCipherController(WithAlphabet, nil).SetMixedAlphabet("ZEBRAS")
+	got, _ := ctrl.Encrypt(crypto.CaesarMode, "Flee at once", 0)
+	if got != "Siaa zq lkba" {
+		t.Errorf("Exp:'%s' Got:'%s'", "Siaa zq lkba", got)
+	}
+
+	const PLAIN = "We are discovered, flee at once!"
+	for _, dir := range []crypto.CipherDirection{crypto.StandardDirection, crypto.BeaufortDirection, crypto.VariantBeaufortDirection} {
+		ctrl.SetDirection(dir)
+		enc, _ := ctrl.Encrypt(crypto.FibonacciMode, PLAIN, 3)
+		dec, _ := ctrl.Decrypt(crypto.FibonacciMode, enc, 3)
+		if dec != PLAIN {
+			t.Errorf("%s round-trip Exp:'%s' Got:'%s'", dir, PLAIN, dec)
+		}
+	}
+}
+
+// Affine over the English and the (multi-byte) Czech alphabets, and the
+// correction of multipliers that are not coprime with the alphabet length.
+func Test_AffineMode(t *testing.T) {
+	WithAlphabet := caesardisk.AlphabetFactory("English")
+	ctrl := crypto.NewCipherController(WithAlphabet, nil)
+
+	got, err := ctrl.Encrypt(crypto.AffineMode, "AFFINE CIPHER", 8, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != "IHHWVC SWFRCP" {
+		t.Errorf("Exp:'%s' Got:'%s'", "IHHWVC SWFRCP", got)
+	}
+
+	vectors := []struct {
+		Multiplier  int
+		Expect      int
+		WithWarning bool
+	}{
+		{5, 5, false},
+		{13, 11, true}, // 12 & 14 are even, tie goes to the smaller
+		{2, 1, true},
+		{0, 1, true},
+		{-3, 23, true},
+	}
+	for i, v := range vectors {
+		got, warn := ctrl.AffineCorrection(v.Multiplier)
+		if (warn != nil) != v.WithWarning {
+			t.Errorf("#%d warning expected:%t got:%v", i+1, v.WithWarning, warn)
+		}
+		if got != v.Expect {
+			t.Errorf("#%d Exp:%d Got:%d", i+1, v.Expect, got)
+		}
+	}
+	if _, err := ctrl.Encrypt(crypto.AffineMode, "Hello", 3, 13); err == nil {
+		t.Error("a multiplier that is not coprime must be reported")
+	}
+
+	czech := crypto.NewCipherController(caesardisk.AlphabetFactory("CZ"), nil)
+	const PLAIN = "Příliš žluťoučký kůň úpěl ďábelské ódy"
+	enc, err := czech.Encrypt(crypto.AffineMode, PLAIN, 7, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dec, _ := czech.Decrypt(crypto.AffineMode, enc, 7, 5)
+	if dec != PLAIN {
+		t.Errorf("round-trip Exp:'%s' Got:'%s'", PLAIN, dec)
+	}
+}
+
+// Atbash reflects the alphabet and is reciprocal for every key.
+func Test_AtbashMode(t *testing.T) {
+	WithAlphabet := caesardisk.AlphabetFactory("English")
+	ctrl := crypto.NewCipherController(WithAlphabet, nil)
+
+	got, _ := ctrl.Encrypt(crypto.AtbashMode, "Wizard of Oz", 0)
+	if got != "Draziw lu La" {
+		t.Errorf("Exp:'%s' Got:'%s'", "Draziw lu La", got)
+	}
+
+	const PLAIN = "Ab ovo usque ad mala"
+	for key := range WithAlphabet.Length() {
+		enc, _ := ctrl.Encrypt(crypto.AtbashMode, PLAIN, key)
+		again, _ := ctrl.Encrypt(crypto.AtbashMode, enc, key)
+		if again != PLAIN {
+			t.Errorf("key %d not reciprocal: '%s' -> '%s'", key, enc, again)
+		}
+	}
+}
+
+// A Lucas series (2,1) with 15 terms instead of the default Fibonacci.
+func Test_FibonacciSeries(t *testing.T) {
+	WithAlphabet := caesardisk.AlphabetFactory("English")
+	ctrl := crypto.NewCipherController(WithAlphabet, nil)
+
+	const PLAIN = "Nine men's morris is filled up with mud"
+	std, _ := ctrl.Encrypt(crypto.FibonacciMode, PLAIN, 4)
+	dflt, _ := ctrl.Encrypt(crypto.FibonacciMode, PLAIN, 4, crypto.DefaultFibonacciSeries)
+	if std != dflt {
+		t.Errorf("default series Exp:'%s' Got:'%s'", std, dflt)
+	}
+
+	lucas := crypto.FibonacciSeries{Terms: 15, Seed0: 2, Seed1: 1}
+	schedule, _ := ctrl.GetFibonacciSchedule(0, lucas)
+	if len(schedule) != 15 {
+		t.Fatalf("schedule length Exp:15 Got:%d", len(schedule))
+	}
+	// 2 1 3 4 7 11 18 29%26
+	for i, exp := range []int{2, 1, 3, 4, 7, 11, 18, 3} {
+		if schedule[i].KeyShift != exp {
+			t.Errorf("#%d Exp:%d Got:%d", i, exp, schedule[i].KeyShift)
+		}
+	}
+
+	enc, err := ctrl.Encrypt(crypto.FibonacciMode, PLAIN, 4, lucas)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if dec, _ := ctrl.Decrypt(crypto.FibonacciMode, enc, 4, lucas); dec != PLAIN {
+		t.Errorf("round-trip Exp:'%s' Got:'%s'", PLAIN, dec)
+	}
+	if _, err := ctrl.Encrypt(crypto.FibonacciMode, PLAIN, 4, crypto.FibonacciSeries{}); err == nil {
+		t.Error("a series without terms must be reported")
+	}
+}
+
+// Primus over a window of 20 primes starting at the 5th prime (11).
+func Test_PrimeWindow(t *testing.T) {
+	WithAlphabet := caesardisk.AlphabetFactory("English")
+	ctrl := crypto.NewCipherController(WithAlphabet, nil)
+	if ctrl.PrimusMaximus() != 12 {
+		t.Errorf("default maximum Exp:12 Got:%d", ctrl.PrimusMaximus())
+	}
+
+	ctrl.SetPrimeWindow(crypto.PrimeWindow{Start: 4, Terms: 20})
+	if ctrl.PrimusMaximus() != 21 {
+		t.Errorf("maximum Exp:21 Got:%d", ctrl.PrimusMaximus())
+	}
+	schedule, _ := ctrl.GetPrimusSchedule(0, 0)
+	if len(schedule) != 21 {
+		t.Fatalf("schedule length Exp:21 Got:%d", len(schedule))
+	}
+	// 0 11 13 17 19 23 29%26 31%26 ... the 24th prime is 89
+	for i, exp := range []int{0, 11, 13, 17, 19, 23, 3, 5} {
+		if schedule[i].KeyShift != exp {
+			t.Errorf("#%d Exp:%d Got:%d", i, exp, schedule[i].KeyShift)
+		}
+	}
+	if last := schedule[20].KeyShift; last != 89%26 {
+		t.Errorf("last Exp:%d Got:%d", 89%26, last)
+	}
+
+	const PLAIN = "Primes are the atoms of arithmetic"
+	enc, err := ctrl.Encrypt(crypto.PrimusMode, PLAIN, 7, 15)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	other, _ := ctrl.CloneWith(nil).SetPrimeWindow(crypto.DefaultPrimeWindow).Encrypt(crypto.PrimusMode, PLAIN, 7, 15)
+	if enc == other {
+		t.Error("different prime windows gave the same ciphertext")
+	}
+	if dec, _ := ctrl.Decrypt(crypto.PrimusMode, enc, 7, 15); dec != PLAIN {
+		t.Errorf("round-trip Exp:'%s' Got:'%s'", PLAIN, dec)
+	}
+}
+
+// Xorshift key stream is reproducible, long and depends on every seed.
+func Test_XorshiftMode(t *testing.T) {
+	WithAlphabet := caesardisk.AlphabetFactory("English")
+	ctrl := crypto.NewCipherController(WithAlphabet, nil)
+
+	const PLAIN = "The quick brown fox jumps over the lazy dog and then some more to see"
+	enc, err := ctrl.Encrypt(crypto.XorshiftMode, PLAIN, 3, 5, "open sesame")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if dec, _ := ctrl.Decrypt(crypto.XorshiftMode, enc, 3, 5, "open sesame"); dec != PLAIN {
+		t.Errorf("round-trip Exp:'%s' Got:'%s'", PLAIN, dec)
+	}
+	for _, other := range [][]any{{6, "open sesame"}, {5, "open sesamE"}} {
+		if got, _ := ctrl.Encrypt(crypto.XorshiftMode, PLAIN, 3, other...); got == enc {
+			t.Errorf("seed %v gave the same ciphertext", other)
+		}
+	}
+
+	schedule, _ := ctrl.GetXorshiftSchedule(3, 5, "open sesame")
+	if len(schedule) != WithAlphabet.Length() {
+		t.Errorf("preview length Exp:%d Got:%d", WithAlphabet.Length(), len(schedule))
+	}
+	// a repeating string exposes a short period, if any
+	const REPEAT = "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"
+	stream, _ := ctrl.Encrypt(crypto.XorshiftMode, REPEAT, 0, 0)
+	for period := 1; period <= 12; period++ {
+		if stream[period:] == stream[:len(stream)-period] {
+			t.Errorf("key stream repeats every %d letters: %s", period, stream)
+		}
+	}
+}
+
+// Passphrase derived keys are deterministic, normalized and per alphabet.
+func Test_DeriveKeyFromPassphrase(t *testing.T) {
+	english := caesardisk.AlphabetFactory("English")
+
+	key, err := crypto.DeriveKeyFromPassphrase("Correct horse battery staple", english)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	again, _ := crypto.DeriveKeyFromPassphrase("  correct HORSE  battery staple ", english)
+	if key != again {
+		t.Errorf("not deterministic %v vs %v", key, again)
+	}
+	if key.MainKey.Shift == 0 || key.Offset == 0 || key.AltKey.Shift == 0 {
+		t.Errorf("a derived key must never be zero: %v", key)
+	}
+	if key.MixKeyword != "CORRECT HORSE BATTERY STAPLE" {
+		t.Errorf("mixing keyword Got:'%s'", key.MixKeyword)
+	}
+
+	differ := false
+	for _, other := range []string{"Correct horse battery stapler", "Tr0ub4dor&3", "ZEBRAS"} {
+		derived, _ := crypto.DeriveKeyFromPassphrase(other, english)
+		differ = differ || derived.MainKey != key.MainKey || derived.Offset != key.Offset
+	}
+	if !differ {
+		t.Error("different passphrases all derived the same keys")
+	}
+
+	for _, name := range []string{"ES", "DE", "GR", "CZ"} {
+		alpha := caesardisk.AlphabetFactory(name)
+		derived, _ := crypto.DeriveKeyFromPassphrase("Correct horse battery staple", alpha)
+		if derived.MainKey.Shift < 1 || derived.MainKey.Shift >= alpha.Length() {
+			t.Errorf("%s main key out of range: %v", name, derived.MainKey)
+		}
+	}
+
+	if _, err := crypto.DeriveKeyFromPassphrase("   ", english); err == nil {
+		t.Error("an empty passphrase must be rejected")
+	}
+}
+
+// Streaming splits multi-byte runes across buffers yet gives the same
+// result as the string API.
+func Test_StreamingTranscoder(t *testing.T) {
+	czech := caesardisk.AlphabetFactory("CZ")
+	ctrl := crypto.NewCipherController(czech, nil)
+
+	const PLAIN = "Příliš žluťoučký kůň úpěl ďábelské ódy. "
+	text := strings.Repeat(PLAIN, 300) // larger than a read chunk
+	expect, _ := ctrl.Encrypt(crypto.FibonacciMode, text, 5)
+
+	var ciphered bytes.Buffer
+	w, err := ctrl.NewEncryptingWriter(crypto.FibonacciMode, &ciphered, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	data := []byte(text)
+	for len(data) > 0 {
+		n := min(3, len(data)) // splits most of the 2-byte runes
+		w.Write(data[:n])
+		data = data[n:]
+	}
+	w.Close()
+	if ciphered.String() != expect {
+		t.Fatalf("streamed encryption differs from Encrypt()")
+	}
+
+	r, _ := ctrl.NewDecryptingReader(crypto.FibonacciMode, iotest.OneByteReader(&ciphered), 5)
+	plain, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(plain) != text {
+		t.Errorf("streamed round-trip differs")
+	}
+}
+
+// The same Caesar object gives the same result for the same message,
+// unless the stream is explicitly continued.
+func Test_SequencerResetAndClone(t *testing.T) {
+	params := cipher.NewCaesarParameters(caesardisk.AlphabetFactory("English"))
+	params.SetKey(4)
+	caesar := cipher.NewFibonacciCipher(params)
+
+	const PART1, PART2 = "Arma virumque cano", " Troiae qui primus ab oris"
+	first := caesar.Encode(PART1)
+	if again := caesar.Encode(PART1); again != first {
+		t.Errorf("fresh message Exp:'%s' Got:'%s'", first, again)
+	}
+
+	caesar.ContinueStream(true).Reset()
+	multipart := caesar.Encode(PART1) + caesar.Encode(PART2)
+	caesar.ContinueStream(false)
+	if whole := caesar.Encode(PART1 + PART2); whole != multipart {
+		t.Errorf("continued stream Exp:'%s' Got:'%s'", whole, multipart)
+	}
+
+	seq := cipher.NewPrimusSequencer(params)
+	seq.NextKey()
+	seq.NextKey()
+	clone := seq.Clone()
+	for i := range 15 {
+		if a, b := seq.NextKey(), clone.NextKey(); a != b {
+			t.Fatalf("#%d clone diverged %d vs %d", i, a, b)
+		}
+	}
+	clone.GetParams().KeyValue = 9
+	if seq.GetParams().KeyValue != 4 {
+		t.Error("clone parameters are not independent")
+	}
+}
+
+// A line from the middle of a long message is decrypted on its own.
+func Test_DecryptFragment(t *testing.T) {
+	ctrl := crypto.NewCipherController(caesardisk.AlphabetFactory("English"), nil)
+
+	const PLAIN = "Line one is here.\nSecond line, with commas!\nAnd the third line."
+	runic := []rune(PLAIN)
+	from := strings.Index(PLAIN, "Second")
+	to := strings.Index(PLAIN, "\nAnd")
+	for _, mode := range []crypto.CaesarCipherMode{crypto.CaesarMode, crypto.DidimusMode, crypto.FibonacciMode, crypto.PrimusMode, crypto.XorshiftMode} {
+		var args []any
+		if mode == crypto.DidimusMode || mode == crypto.PrimusMode || mode == crypto.XorshiftMode {
+			args = append(args, 5)
+		}
+		message, _ := ctrl.Encrypt(mode, PLAIN, 11, args...)
+		got, err := ctrl.DecryptFragment(mode, message, from, to, 11, args...)
+		if err != nil {
+			t.Fatalf("%s unexpected error: %s", mode, err)
+		}
+		if got != string(runic[from:to]) {
+			t.Errorf("%s Exp:'%s' Got:'%s'", mode, string(runic[from:to]), got)
+		}
+	}
+
+	message, _ := ctrl.Encrypt(crypto.AutokeyMode, PLAIN, 3)
+	if _, err := ctrl.DecryptFragment(crypto.AutokeyMode, message, from, to, 3); err == nil {
+		t.Error("Autokey cannot seek past the primer")
+	}
+}
+
+// A negative Primus offset selects no primes at all and a missing one
+// all of them, seeking must not divide by an empty period.
+func Test_PrimusSeekWithoutOffset(t *testing.T) {
+	ctrl := crypto.NewCipherController(caesardisk.AlphabetFactory("English"), nil)
+
+	const PLAIN = "HELLO WORLD"
+	for _, args := range [][]any{nil, {-1}, {-7}} {
+		message, _ := ctrl.Encrypt(crypto.PrimusMode, PLAIN, 3, args...)
+		got, err := ctrl.DecryptFragment(crypto.PrimusMode, message, 3, 8, 3, args...)
+		if err != nil || got != PLAIN[3:8] {
+			t.Errorf("offset %v Exp:'%s' Got:'%s' %v", args, PLAIN[3:8], got, err)
+		}
+	}
+}
+
+func Test_KeyingPolicy(t *testing.T) {
+	ctrl := crypto.NewCipherController(caesardisk.AlphabetFactory("English"), nil)
+
+	const PLAIN = "AB CD, EF"
+	skipped, _ := ctrl.Encrypt(crypto.FibonacciMode, PLAIN, 0)
+	ctrl.SetKeyingPolicy(crypto.CountAllKeying)
+	counted, _ := ctrl.Encrypt(crypto.FibonacciMode, PLAIN, 0)
+	// F = 0,1,1,2,3,5,8,13,21 skipped: A,B,C,D,E,F counted: A,B,_,C,D,_,_,E,F
+	if skipped != "AC DF, HK" || counted != "AC EG, RA" {
+		t.Errorf("Exp:'AC DF, HK' & 'AC EG, RA' Got:'%s' & '%s'", skipped, counted)
+	}
+
+	if plain, _ := ctrl.Decrypt(crypto.FibonacciMode, counted, 0); plain != PLAIN {
+		t.Errorf("round-trip Exp:'%s' Got:'%s'", PLAIN, plain)
+	}
+	if part, _ := ctrl.DecryptFragment(crypto.FibonacciMode, counted, 7, 9, 0); part != "EF" {
+		t.Errorf("fragment Exp:'EF' Got:'%s'", part)
+	}
+}
+
+func Test_TextNormalizer(t *testing.T) {
+	testCases := []struct {
+		Alpha string
+		Plain string
+		Exp   string
+	}{
+		{"EN", "Ése niño, ¡qué Æsop!", "EseninoqueAEsop"},
+		{"ES", "Ése niño, ¡qué Æsop!", "EseniñoqueAEsop"},
+		{"CZ", "Příliš žluťoučký kůň", "Přílišžluťoučkýkůň"},
+		{"DE", "Straße", "Straße"},
+		{"EN", "Straße", "Strasse"},
+		{"EN", "STRAẞE", "STRASSE"},
+	}
+	for _, tc := range testCases {
+		normalizer := crypto.NewTextNormalizer(caesardisk.AlphabetFactory(tc.Alpha), crypto.ClassicNormalization)
+		if got := normalizer.Normalize(tc.Plain); got != tc.Exp {
+			t.Errorf("%s Exp:'%s' Got:'%s'", tc.Alpha, tc.Exp, got)
+		}
+	}
+
+	// the sharp S is folded to the case of an alphabet that has only ß
+	lower := caesardisk.NewAlphabetModel("abcdefghijklmnopqrstuvwxyzäöüß")
+	if got := crypto.NewTextNormalizer(lower, crypto.ClassicNormalization).Normalize("straẞe"); got != "straße" {
+		t.Errorf("lower-case alphabet Exp:'straße' Got:'%s'", got)
+	}
+
+	ctrl := crypto.NewCipherController(caesardisk.AlphabetFactory("EN"), nil)
+	ctrl.SetNormalization(crypto.ClassicNormalization)
+	ciphered, _ := ctrl.Encrypt(crypto.CaesarMode, "Attack at dawn, señor!", 3)
+	if ciphered != "Dwwdf ndwgd zqvhq ru" {
+		t.Errorf("grouped Exp:'Dwwdf ndwgd zqvhq ru' Got:'%s'", ciphered)
+	}
+	if plain, _ := ctrl.Decrypt(crypto.CaesarMode, ciphered, 3); plain != "Attackatdawnsenor" {
+		t.Errorf("round-trip Exp:'Attackatdawnsenor' Got:'%s'", plain)
+	}
+
+	// a fragment of the grouped ciphertext is ungrouped as well, and the
+	// grouping spaces do not take key positions
+	for _, keying := range []crypto.KeyingPolicy{crypto.SkipForeignKeying, crypto.CountAllKeying} {
+		ctrl.SetKeyingPolicy(keying)
+		ciphered, _ := ctrl.Encrypt(crypto.FibonacciMode, "Attack at dawn, señor!", 3)
+		if part, err := ctrl.DecryptFragment(crypto.FibonacciMode, ciphered, 4, 14, 3); err != nil || part != "ckatdawn" {
+			t.Errorf("%v fragment Exp:'ckatdawn' Got:'%s' %v", keying, part, err)
+		}
+	}
+}
+
+// Decomposed (NFD) and precomposed (NFC) text encrypt identically in
+// every built-in alphabet, and decrypt to NFC.
+func Test_UnicodeNormalization(t *testing.T) {
+	for _, name := range []string{"EN", "ES", "ES-XTR", "CZ", "DE", "IT", "PT", "RU", "GR", "PU", "PU-ES", "PU-EN", "Runes"} {
+		alpha := caesardisk.AlphabetFactory(name)
+		ctrl := crypto.NewCipherController(alpha, nil)
+
+		text := alpha.String() + " " + strings.ToLower(alpha.String())
+		decomposed := norm.NFD.String(text)
+		expect, _ := ctrl.Encrypt(crypto.FibonacciMode, text, 3)
+		if got, _ := ctrl.Encrypt(crypto.FibonacciMode, decomposed, 3); got != expect {
+			t.Errorf("%s NFD Exp:'%s' Got:'%s'", name, expect, got)
+		}
+		if got, _ := ctrl.Decrypt(crypto.FibonacciMode, norm.NFD.String(expect), 3); got != text {
+			t.Errorf("%s round-trip Exp:'%s' Got:'%s'", name, text, got)
+		}
+
+		var streamed bytes.Buffer
+		w, _ := ctrl.NewEncryptingWriter(crypto.FibonacciMode, &streamed, 3)
+		for _, b := range []byte(decomposed) {
+			w.Write([]byte{b})
+		}
+		w.Close()
+		if streamed.String() != expect {
+			t.Errorf("%s streamed NFD Exp:'%s' Got:'%s'", name, expect, streamed.String())
+		}
+	}
+}
+
+// On the dual disk digits & punctuation turn with the letters.
+func Test_DualDisk(t *testing.T) {
+	english := caesardisk.AlphabetFactory("EN")
+	ctrl := crypto.NewCipherController(english, nil)
+	ctrl.SetSymbolAlphabet(caesardisk.DualSymbolsFor(english))
+
+	const PLAIN = "Pay $45 at 10:30!"
+	if got, _ := ctrl.Encrypt(crypto.CaesarMode, PLAIN, 3); got != "Sdb2(782dw243:63$" {
+		t.Errorf("Exp:'Sdb2(782dw243:63$' Got:'%s'", got)
+	}
+
+	ciphered, _ := ctrl.Encrypt(crypto.FibonacciMode, PLAIN, 7)
+	if plain, _ := ctrl.Decrypt(crypto.FibonacciMode, ciphered, 7); plain != PLAIN {
+		t.Errorf("round-trip Exp:'%s' Got:'%s'", PLAIN, plain)
+	}
+	if part, _ := ctrl.DecryptFragment(crypto.FibonacciMode, ciphered, 11, 16, 7); part != "10:30" {
+		t.Errorf("fragment Exp:'10:30' Got:'%s'", part)
+	}
+
+	ctrl.SetSymbolAlphabet(caesardisk.AlphabetFactory("PU-ES"))
+	if _, err := ctrl.Encrypt(crypto.CaesarMode, PLAIN, 3); err != caesardisk.ErrUnmatchedDual {
+		t.Errorf("Exp: %v Got: %v", caesardisk.ErrUnmatchedDual, err)
+	}
+}
+
+// a custom key sequencer for Test_EngineAPI: the progressive key of
+// Trithemius, K(i) = Key + i
+type trithemiusSequencer struct {
+	engine.BaseSequencer
+	index int
+}
+
+func (ts *trithemiusSequencer) NextKey() int {
+	p := ts.GetParams()
+	ts.index++
+	return (p.KeyValue + ts.index - 1) % p.Alphabet.Length()
+}
+
+func (ts *trithemiusSequencer) Reset() {
+	ts.index = 0
+}
+
+func (ts *trithemiusSequencer) IsPolyalphabetic() bool {
+	return true
+}
+
+func (ts *trithemiusSequencer) Clone() engine.IKeySequencer {
+	clone := *ts
+	params := *ts.GetParams()
+	clone.BaseSequencer = *engine.NewBaseSequencer(&params)
+	return &clone
+}
+
+// a custom sequencer that does not override Clone()
+type forgetfulSequencer struct {
+	engine.BaseSequencer
+}
+
+// The public engine gives the same results as the CipherController
+// and accepts custom cipher modes.
+func Test_EngineAPI(t *testing.T) {
+	english := caesardisk.AlphabetFactory("EN")
+	caesar, err := engine.New(engine.VigenereMode, english, engine.Options{Keyword: "LEMON"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := caesar.Encode("ATTACK AT DAWN"); got != "LXFOPV EF RNHR" {
+		t.Errorf("Vigenère Exp:'LXFOPV EF RNHR' Got:'%s'", got)
+	}
+
+	opts := engine.Options{Key: 5, Offset: 7, Direction: engine.BeaufortDirection}
+	caesar, _ = engine.New(engine.DidimusMode, english, opts)
+	expect, _ := crypto.NewCipherController(english, nil).SetDirection(crypto.BeaufortDirection).Encrypt(crypto.DidimusMode, "Hello World", 5, 7)
+	if got := caesar.Encode("Hello World"); got != expect {
+		t.Errorf("Didimus Exp:'%s' Got:'%s'", expect, got)
+	}
+
+	// a missing offset is zero through both APIs
+	ctrl := crypto.NewCipherController(english, nil)
+	for mode, engineMode := range map[crypto.CaesarCipherMode]engine.Mode{
+		crypto.PrimusMode:   engine.PrimusMode,
+		crypto.XorshiftMode: engine.XorshiftMode,
+	} {
+		caesar, _ = engine.New(engineMode, english, engine.Options{Key: 5})
+		expect, _ := ctrl.Encrypt(mode, "Hello World", 5)
+		if got := caesar.Encode("Hello World"); got != expect {
+			t.Errorf("%s Exp:'%s' Got:'%s'", mode, expect, got)
+		}
+	}
+
+	if _, err := engine.New("Enigma", english, engine.Options{}); err == nil {
+		t.Error("expected error for an unregistered mode")
+	}
+
+	trithemius := func(p *engine.Parameters, _ engine.Options) engine.IKeySequencer {
+		return &trithemiusSequencer{BaseSequencer: *engine.NewBaseSequencer(p)}
+	}
+	if err := engine.Register("Trithemius", trithemius); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := engine.Register(engine.CaesarMode, trithemius); err == nil {
+		t.Error("expected error for a duplicate mode")
+	}
+	caesar, _ = engine.New("Trithemius", english, engine.Options{Key: 1})
+	if got := caesar.Encode("aaaa aaaa"); got != "bcde fghi" {
+		t.Errorf("Trithemius Exp:'bcde fghi' Got:'%s'", got)
+	}
+
+	seq := &trithemiusSequencer{BaseSequencer: *engine.NewBaseSequencer(engine.NewParameters(english))}
+	seq.NextKey()
+	if clone, ok := seq.Clone().(*trithemiusSequencer); !ok || clone.NextKey() != seq.NextKey() {
+		t.Errorf("the clone lost the custom type or its state: %T", seq.Clone())
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a panic cloning without a Clone() override")
+			}
+		}()
+		forgetful := &forgetfulSequencer{BaseSequencer: *engine.NewBaseSequencer(engine.NewParameters(english))}
+		forgetful.Clone()
+	}()
+
+	typed := []struct {
+		ctor func() (*engine.Caesar, error)
+		mode engine.Mode
+		opts engine.Options
+	}{
+		{func() (*engine.Caesar, error) { return engine.NewVigenere(english, "LEMON") }, engine.VigenereMode, engine.Options{Keyword: "LEMON"}},
+		{func() (*engine.Caesar, error) { return engine.NewAutokey(english, "KEY", true) }, engine.AutokeyCipherMode, engine.Options{Keyword: "KEY"}},
+		{func() (*engine.Caesar, error) { return engine.NewAffine(english, 3, 5) }, engine.AffineMode, engine.Options{Key: 3, Multiplier: 5}},
+		{func() (*engine.Caesar, error) { return engine.NewPrimus(english, 4, 3) }, engine.PrimusMode, engine.Options{Key: 4, Offset: 3}},
+	}
+	for _, tc := range typed {
+		caesar, err := tc.ctor()
+		if err != nil {
+			t.Fatalf("%s unexpected error: %s", tc.mode, err)
+		}
+		expect, _ := engine.New(tc.mode, english, tc.opts)
+		if got, exp := caesar.Encode("Hello World"), expect.Encode("Hello World"); got != exp {
+			t.Errorf("typed %s Exp:'%s' Got:'%s'", tc.mode, exp, got)
+		}
+	}
+	if _, err := engine.NewAffine(english, 3, 13); err == nil {
+		t.Error("expected error for a multiplier not coprime with 26")
+	}
+}
+
+// The controller discovers the cipher modes & their requirements from
+// the registry, including custom modes.
+func Test_CipherModeRegistry(t *testing.T) {
+	english := caesardisk.AlphabetFactory("EN")
+	ctrl := crypto.NewCipherController(english, nil)
+	for _, mode := range crypto.CipherModes() {
+		if parsed, err := crypto.ParseCipherMode(mode.String()); err != nil || parsed != mode {
+			t.Errorf("%s does not parse back: %v", mode, err)
+		}
+		offsetModes := mode == crypto.DidimusMode || mode == crypto.PrimusMode || mode == crypto.XorshiftMode
+		if mode.UsesOffset() != offsetModes {
+			t.Errorf("%s UsesOffset() should be %t", mode, offsetModes)
+		}
+	}
+	if _, max := ctrl.OffsetRange(crypto.PrimusMode); max != ctrl.PrimusMaximus()-1 {
+		t.Errorf("Primus Offset max Exp:%d Got:%d", ctrl.PrimusMaximus()-1, max)
+	}
+	if _, err := ctrl.Encrypt(crypto.DidimusMode, "Hello", 3); err == nil {
+		t.Error("expected error for a missing Didimus offset")
+	}
+
+	schedule, err := ctrl.GetKeySchedule(crypto.VigenereMode, 0, "LEMON")
+	if err != nil || len(schedule) != 5 || schedule[1].KeyChar != 'E' {
+		t.Errorf("Vigenère schedule of LEMON: %v %v", schedule, err)
+	}
+	if _, err := ctrl.GetVigenereSchedule("123"); err == nil {
+		t.Error("expected error for a Vigenère keyword without letters")
+	}
+
+	progressive, err := crypto.RegisterCipherMode(crypto.CipherModeSpec{
+		Name: "Progressive",
+		Factory: func(p *engine.Parameters, _ engine.Options) engine.IKeySequencer {
+			return &trithemiusSequencer{BaseSequencer: *engine.NewBaseSequencer(p)}
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, _ := ctrl.Encrypt(progressive, "aaaa aaaa", 1); got != "bcde fghi" {
+		t.Errorf("Progressive Exp:'bcde fghi' Got:'%s'", got)
+	}
+	if _, err := crypto.RegisterCipherMode(crypto.CipherModeSpec{Name: "Caesar"}); err == nil {
+		t.Error("expected error for a duplicate mode")
+	}
+}
+
+// A PDU unpacks to the plain text with the same extra arguments as
+// Decrypt() in every registered mode.
+func Test_PackUnpackMessage(t *testing.T) {
+	ctrl := crypto.NewCipherController(caesardisk.AlphabetFactory("English"), nil)
+
+	const PLAIN = "Meet me at the old mill"
+	for _, mode := range crypto.CipherModes() {
+		spec, _ := crypto.LookupCipherMode(mode)
+		var args []any
+		for _, param := range spec.Params {
+			switch param {
+			case crypto.OffsetParam:
+				args = append(args, 5)
+			case crypto.MultiplierParam:
+				args = append(args, 5)
+			case crypto.KeywordParam, crypto.PassphraseParam:
+				args = append(args, "LEMON")
+			case crypto.SeriesParam:
+				args = append(args, crypto.FibonacciSeries{Terms: 7, Seed0: 1, Seed1: 2})
+			}
+		}
+
+		ciphered, err := ctrl.Encrypt(mode, PLAIN, 3, args...)
+		if err != nil {
+			t.Fatalf("%s unexpected error: %s", mode, err)
+		}
+		got, err := ctrl.UnpackMessage(ctrl.PackMessage(ciphered), mode, 3, args...)
+		if err != nil || got != PLAIN {
+			t.Errorf("%s Exp:'%s' Got:'%s' %v", mode, PLAIN, got, err)
+		}
+	}
+}
+
+// The settings of a controller may be read while other goroutines
+// change them & encrypt (run with -race).
+func Test_ControllerConcurrency(t *testing.T) {
+	ctrl := crypto.NewCipherController(caesardisk.AlphabetFactory("English"), nil)
+
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				ctrl.SetDirection(crypto.CipherDirection(i % 2))
+				ctrl.SetKeyingPolicy(crypto.KeyingPolicy(i % 2))
+				_ = ctrl.Direction()
+				_ = ctrl.KeyingPolicy()
+				_ = ctrl.CipherAlphabet()
+				_, _ = ctrl.OffsetRange(crypto.PrimusMode)
+				_, _ = ctrl.Encrypt(crypto.FibonacciMode, "Hello World", 3)
+				_ = ctrl.CloneWith(nil)
+			}
+		}()
+	}
+	wg.Wait()
+}
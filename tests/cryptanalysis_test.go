@@ -0,0 +1,214 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/lordofscripts/caesardisk"
+	"github.com/lordofscripts/caesardisk/cryptanalysis"
+	"github.com/lordofscripts/caesardisk/crypto"
+	"github.com/lordofscripts/caesardisk/engine"
+)
+
+// plain texts of every language with a built-in frequency profile
+var cryptanalysisSamples = []struct {
+	Alpha string
+	Plain string
+}{
+	{"EN", "It was the best of times, it was the worst of times, it was the age of wisdom, it was the age of foolishness."},
+	{"ES", "En un lugar de la Mancha, de cuyo nombre no quiero acordarme, no ha mucho tiempo que vivía un hidalgo de los de lanza en astillero."},
+	{"DE", "Als Gregor Samsa eines Morgens aus unruhigen Träumen erwachte, fand er sich in seinem Bett zu einem ungeheueren Ungeziefer verwandelt."},
+	{"IT", "Nel mezzo del cammin di nostra vita mi ritrovai per una selva oscura, ché la diritta via era smarrita."},
+	{"PT", "As armas e os barões assinalados que da ocidental praia lusitana por mares nunca de antes navegados passaram ainda além da Taprobana."},
+	{"CZ", "Byl pozdní večer, první máj, večerní máj, byl lásky čas. Hrdliččin zval ku lásce hlas, kde borový zaváněl háj."},
+	{"RU", "Все счастливые семьи похожи друг на друга, каждая несчастливая семья несчастлива по своему. Все смешалось в доме Облонских."},
+	{"GR", "ΑΝΔΡΑ ΜΟΙ ΕΝΝΕΠΕ ΜΟΥΣΑ ΠΟΛΥΤΡΟΠΟΝ ΟΣ ΜΑΛΑ ΠΟΛΛΑ ΠΛΑΓΧΘΗ ΕΠΕΙ ΤΡΟΙΗΣ ΙΕΡΟΝ ΠΤΟΛΙΕΘΡΟΝ ΕΠΕΡΣΕ ΠΟΛΛΩΝ Δ ΑΝΘΡΩΠΩΝ ΙΔΕΝ ΑΣΤΕΑ ΚΑΙ ΝΟΟΝ ΕΓΝΩ"},
+}
+
+// The brute-force solver ranks the right Caesar key first in every
+// language with a frequency profile.
+func Test_CaesarSolver(t *testing.T) {
+	for _, v := range cryptanalysisSamples {
+		alpha := caesardisk.AlphabetFactory(v.Alpha)
+		key := alpha.Length() / 3
+		ciphered, err := crypto.NewCipherController(alpha, nil).Encrypt(crypto.CaesarMode, v.Plain, key)
+		if err != nil {
+			t.Fatalf("%s unexpected error: %s", v.Alpha, err)
+		}
+
+		solver, err := cryptanalysis.NewCaesarSolver(alpha)
+		if err != nil {
+			t.Fatalf("%s unexpected error: %s", v.Alpha, err)
+		}
+		candidates, _ := solver.Solve(ciphered)
+		if len(candidates) != alpha.Length() {
+			t.Errorf("%s Exp:%d candidates Got:%d", v.Alpha, alpha.Length(), len(candidates))
+		}
+		if best := candidates[0]; best.Key != key {
+			t.Errorf("%s Exp key:%d Got:%s", v.Alpha, key, best)
+		}
+	}
+
+	if _, err := cryptanalysis.NewCaesarSolver(caesardisk.AlphabetFactory("PU")); err == nil {
+		t.Error("expected error for an alphabet without language profile")
+	}
+}
+
+// Didimus falls to two independent Caesar attacks on the even & odd
+// encodeable characters.
+func Test_DidimusSolver(t *testing.T) {
+	for _, v := range cryptanalysisSamples {
+		alpha := caesardisk.AlphabetFactory(v.Alpha)
+		key, offset := alpha.Length()/3, 5
+		for _, keying := range []crypto.KeyingPolicy{crypto.SkipForeignKeying, crypto.CountAllKeying} {
+			ctrl := crypto.NewCipherController(alpha, nil).SetKeyingPolicy(keying)
+			ciphered, err := ctrl.Encrypt(crypto.DidimusMode, v.Plain, key, offset)
+			if err != nil {
+				t.Fatalf("%s unexpected error: %s", v.Alpha, err)
+			}
+
+			solver, _ := cryptanalysis.NewDidimusSolver(alpha)
+			got, err := solver.SetKeyingPolicy(keying).Solve(ciphered)
+			if err != nil || got.Main != key || got.Offset != offset || got.Alt != key+offset {
+				t.Errorf("%s %s Exp:%d+%d Got:%s %v", v.Alpha, keying, key, offset, got, err)
+			}
+		}
+	}
+
+	english := caesardisk.AlphabetFactory("EN")
+	solver, _ := cryptanalysis.NewDidimusSolver(english)
+	if _, err := solver.Solve("X"); err == nil {
+		t.Error("expected error for a single letter")
+	}
+}
+
+// Fibonacci & Primus reduce to the main key (and Primus offset) since
+// the series over the main key is public.
+func Test_PeriodicSolver(t *testing.T) {
+	for _, v := range cryptanalysisSamples {
+		alpha := caesardisk.AlphabetFactory(v.Alpha)
+		key := alpha.Length() / 3
+		ctrl := crypto.NewCipherController(alpha, nil)
+		solver, _ := cryptanalysis.NewPeriodicSolver(alpha)
+
+		ciphered, _ := ctrl.Encrypt(crypto.FibonacciMode, v.Plain, key)
+		candidates, err := solver.SolveFibonacci(ciphered)
+		if err != nil || candidates[0].Key != key {
+			t.Errorf("%s Fibonacci Exp:%d Got:%s %v", v.Alpha, key, candidates[0], err)
+		}
+
+		for _, offset := range []int{0, 4} {
+			ciphered, _ = ctrl.Encrypt(crypto.PrimusMode, v.Plain, key, offset)
+			candidates, err = solver.Solve(ciphered)
+			if best := candidates[0]; err != nil || best.Mode != "Primus" || best.Key != key || best.Offset != offset {
+				t.Errorf("%s Primus Exp:%d,%d Got:%s %v", v.Alpha, key, offset, best, err)
+			}
+		}
+	}
+}
+
+// The period tests need longer ciphertexts than the key attacks
+var identificationSamples = []struct {
+	Alpha string
+	Plain string
+}{
+	{"EN", "It was the best of times, it was the worst of times, it was the age of wisdom, it was the age of foolishness, it was the epoch of belief, it was the epoch of incredulity, it was the season of Light, it was the season of Darkness, it was the spring of hope, it was the winter of despair, we had everything before us, we had nothing before us, we were all going direct to Heaven, we were all going direct the other way."},
+	{"ES", "En un lugar de la Mancha, de cuyo nombre no quiero acordarme, no ha mucho tiempo que vivía un hidalgo de los de lanza en astillero, adarga antigua, rocín flaco y galgo corredor. Una olla de algo más vaca que carnero, salpicón las más noches, duelos y quebrantos los sábados, lantejas los viernes, algún palomino de añadidura los domingos, consumían las tres partes de su hacienda."},
+}
+
+// The cipher mode & its key period are identified from the ciphertext
+// alone with either keying policy.
+func Test_ModeIdentifier(t *testing.T) {
+	for _, v := range identificationSamples {
+		alpha := caesardisk.AlphabetFactory(v.Alpha)
+		for _, keying := range []crypto.KeyingPolicy{crypto.SkipForeignKeying, crypto.CountAllKeying} {
+			ctrl := crypto.NewCipherController(alpha, nil).SetKeyingPolicy(keying)
+			identifier, err := cryptanalysis.NewModeIdentifier(alpha)
+			if err != nil {
+				t.Fatalf("%s unexpected error: %s", v.Alpha, err)
+			}
+			identifier.SetKeyingPolicy(keying)
+
+			for _, c := range []struct {
+				Mode   crypto.CaesarCipherMode
+				Period int
+				Args   []any
+			}{
+				{crypto.CaesarMode, 1, nil},
+				{crypto.DidimusMode, 2, []any{5}},
+				{crypto.FibonacciMode, 10, nil},
+				{crypto.PrimusMode, 12, []any{0}},
+				{crypto.PrimusMode, 5, []any{4}},
+				{crypto.VigenereMode, 5, []any{"LEMON"}},
+				{crypto.AutokeyMode, 0, nil},
+				{crypto.AutokeyCipherMode, 0, nil},
+				{crypto.AffineMode, 1, []any{5}},
+				{crypto.AtbashMode, 1, nil},
+				{crypto.XorshiftMode, 0, nil},
+			} {
+				ciphered, err := ctrl.Encrypt(c.Mode, v.Plain, 7, c.Args...)
+				if err != nil {
+					t.Fatalf("%s %s unexpected error: %s", v.Alpha, c.Mode, err)
+				}
+
+				got, err := identifier.Identify(ciphered)
+				if err != nil || got.Best().Mode != engine.Mode(c.Mode.String()) || got.Period != c.Period {
+					t.Errorf("%s %s %s Exp:%d Got:%s %v", v.Alpha, keying, c.Mode, c.Period, got, err)
+				}
+			}
+		}
+	}
+
+	english := caesardisk.AlphabetFactory("EN")
+	identifier, _ := cryptanalysis.NewModeIdentifier(english)
+	if _, err := identifier.Identify("Too short"); err == nil {
+		t.Error("expected error for a short ciphertext")
+	}
+}
+
+// The statistics only count the letters of the alphabet and estimate
+// the key period of Vigenère under either keying policy.
+func Test_Statistics(t *testing.T) {
+	english := caesardisk.AlphabetFactory("EN")
+	table := cryptanalysis.LetterFrequencyTable("Hello, World!", english)
+	if len(table) != english.Length() {
+		t.Fatalf("Exp:%d letters Got:%d", english.Length(), len(table))
+	}
+	if l := table[english.Find('L')]; l.Letter != 'L' || l.Count != 3 || l.Frequency != 0.3 {
+		t.Errorf("Exp:L 3 0.3 Got:%c %d %v", l.Letter, l.Count, l.Frequency)
+	}
+	if ioc := cryptanalysis.IndexOfCoincidence("AB", english); ioc != 0 {
+		t.Errorf("Exp IoC:0 Got:%v", ioc)
+	}
+
+	for _, v := range identificationSamples {
+		alpha := caesardisk.AlphabetFactory(v.Alpha)
+		profile, _ := cryptanalysis.LanguageProfileFor(alpha)
+		if friedman := cryptanalysis.FriedmanPeriod(v.Plain, alpha, profile); friedman > 1.5 {
+			t.Errorf("%s plain Exp Friedman:~1 Got:%.2f", v.Alpha, friedman)
+		}
+
+		for _, keying := range []crypto.KeyingPolicy{crypto.SkipForeignKeying, crypto.CountAllKeying} {
+			ctrl := crypto.NewCipherController(alpha, nil).SetKeyingPolicy(keying)
+			ciphered, _ := ctrl.Encrypt(crypto.VigenereMode, v.Plain, 0, "LEMON")
+
+			ioc := cryptanalysis.IndexOfCoincidence(ciphered, alpha)
+			if periodic := cryptanalysis.PeriodicIndexOfCoincidence(ciphered, alpha, keying, 5); periodic < ioc+0.02 {
+				t.Errorf("%s %s Exp IoC at period 5 above %.4f Got:%.4f", v.Alpha, keying, ioc, periodic)
+			}
+			if friedman := cryptanalysis.FriedmanPeriod(ciphered, alpha, profile); friedman < 2 {
+				t.Errorf("%s %s Exp Friedman >2 Got:%.2f", v.Alpha, keying, friedman)
+			}
+
+			factors := cryptanalysis.KasiskiFactors(cryptanalysis.KasiskiRepetitions(ciphered, alpha, keying), 12)
+			best := 3
+			for period := 3; period < len(factors); period++ {
+				if factors[period] > factors[best] {
+					best = period
+				}
+			}
+			if best != 5 {
+				t.Errorf("%s %s Exp Kasiski:5 Got:%d %v", v.Alpha, keying, best, factors)
+			}
+		}
+	}
+}
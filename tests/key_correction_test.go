@@ -89,3 +89,27 @@ func Test_DidimusCorrection(t *testing.T) {
 		}
 	}
 }
+
+// Didimus encrypts the odd letters with the alternate key Main+Offset.
+// A round trip does not prove it since unencrypted odd letters survive
+// it as well, hence the known ciphertexts.
+func Test_DidimusAlternateKey(t *testing.T) {
+	ctrl := crypto.NewCipherController(caesardisk.AlphabetFactory("EN"), nil)
+	for _, v := range []struct {
+		Plain  string
+		Main   int
+		Offset int
+		Expect string
+	}{
+		{"aaaa", 1, 2, "bdbd"},
+		{"HELLO WORLD", 3, 2, "KJOQR BRWOI"},
+	} {
+		got, err := ctrl.Encrypt(crypto.DidimusMode, v.Plain, v.Main, v.Offset)
+		if err != nil || got != v.Expect {
+			t.Errorf("%q %d+%d Exp:%q Got:%q %v", v.Plain, v.Main, v.Offset, v.Expect, got, err)
+		}
+		if plain, _ := ctrl.Decrypt(crypto.DidimusMode, got, v.Main, v.Offset); plain != v.Plain {
+			t.Errorf("%q %d+%d round trip Got:%q", v.Plain, v.Main, v.Offset, plain)
+		}
+	}
+}